@@ -1,7 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"glcron/internal/cli"
+	"glcron/internal/services"
 	"glcron/internal/tui"
 	"os"
 
@@ -9,15 +12,56 @@ import (
 )
 
 func main() {
+	// Subcommands like "glcron report reliability" run without the TUI
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
+	configName := flag.String("config", "", "open the configuration with this name")
+	projectURL := flag.String("project-url", "", "open the project with this URL, adding it if its instance is configured")
+	screen := flag.String("screen", "", "screen to show after opening a project: schedules or quick-run (configs stays on the list)")
+	noAutoOpen := flag.Bool("no-auto-open", false, "don't open the project of the current git checkout")
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("%s %s\n", tui.AppName, tui.AppVersion)
+		return
+	}
+
+	startScreen, err := tui.ParseStartScreen(*screen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	output := tui.NewOutput(os.Stdout)
+	opts := tui.StartupOptions{
+		ConfigName: *configName,
+		ProjectURL: *projectURL,
+		Screen:     startScreen,
+		Output:     output,
+	}
+	if !*noAutoOpen && opts.ConfigName == "" && opts.ProjectURL == "" {
+		if dir, err := os.Getwd(); err == nil {
+			opts.GitRemotes = services.GitRemoteURLs(dir)
+		}
+	}
+
 	// Create the bubbletea program
 	p := tea.NewProgram(
-		tui.NewModel(),
+		tui.NewModel(opts),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithOutput(output),
 	)
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if m, ok := final.(tui.Model); ok {
+		m.Close()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,128 @@
+// Package cli implements the glcron subcommands, run without the TUI
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// command is a subcommand, it returns the process exit code
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"lint":   runLint,
+	"report": runReport,
+}
+
+// IsCommand returns true if the first command-line argument names a subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run runs the subcommand named by args[0] and returns the exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown command %q, use %s\n", args[0], strings.Join(commandNames(), ", "))
+		return 2
+	}
+	return cmd(args[1:], stdout, stderr)
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectFlags select the projects a subcommand works on
+type projectFlags struct {
+	configName string
+	projectURL string
+	all        bool
+
+	// Loaded by openProjects, for the settings of the subcommands
+	configFile *models.ConfigFile
+
+	// Set by requests that refreshed OAuth credentials, saved by closeProjects
+	configService  services.ConfigServiceInterface
+	tokenRefreshed atomic.Bool
+}
+
+func (p *projectFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.configName, "config", "", "use the configuration with this name")
+	fs.StringVar(&p.projectURL, "project-url", "", "use the configuration of the project with this URL")
+	fs.BoolVar(&p.all, "all", false, "use every configuration")
+}
+
+// openProjects loads the configurations selected by the flags. Without any, the
+// project of the current git checkout is used, as when launching the TUI.
+// OAuth credentials refreshed by the returned service are saved by closeProjects.
+func (p *projectFlags) openProjects() (services.GitLabServiceInterface, []models.Config, error) {
+	configService := services.NewConfigService()
+	configFile, err := configService.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.configFile = configFile
+	p.configService = configService
+	configs := configService.GetConfigs()
+
+	// Requests may run in parallel, the config is saved once the command is done
+	gitlabService := services.NewGitLabService()
+	gitlabService.OnTokenRefresh(func(creds *models.OAuthCredentials) {
+		p.tokenRefreshed.Store(true)
+	})
+
+	switch {
+	case p.all:
+		if len(configs) == 0 {
+			return nil, nil, fmt.Errorf("no configuration in %s", configService.GetConfigPath())
+		}
+		return gitlabService, configs, nil
+
+	case p.configName != "":
+		idx := services.FindConfigByName(configs, p.configName)
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("no configuration named %q", p.configName)
+		}
+		return gitlabService, configs[idx : idx+1], nil
+
+	case p.projectURL != "":
+		idx := services.FindConfigByProject(configs, p.projectURL)
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("no configuration for %s", p.projectURL)
+		}
+		return gitlabService, configs[idx : idx+1], nil
+	}
+
+	if dir, err := os.Getwd(); err == nil {
+		for _, remote := range services.GitRemoteURLs(dir) {
+			if idx := services.FindConfigByProject(configs, remote); idx >= 0 {
+				return gitlabService, configs[idx : idx+1], nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no project given and the current directory is not a configured project, use --config, --project-url or --all")
+}
+
+// closeProjects saves the OAuth credentials refreshed while the command ran
+func (p *projectFlags) closeProjects(stderr io.Writer) {
+	if !p.tokenRefreshed.Load() {
+		return
+	}
+	if _, err := p.configService.SaveRefreshedCredentials(); err != nil {
+		fmt.Fprintf(stderr, "Error: failed to save refreshed OAuth token: %v\n", err)
+	}
+}
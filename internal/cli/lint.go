@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"glcron/internal/lint"
+	"glcron/internal/models"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// lintResult is a finding on a schedule of a project
+type lintResult struct {
+	project  models.Config
+	schedule models.Schedule
+	finding  lint.Finding
+}
+
+// scheduleURL links to the edit page of the schedule
+func (r lintResult) scheduleURL() string {
+	return fmt.Sprintf("%s/-/pipeline_schedules/%d/edit", strings.TrimSuffix(r.project.ProjectURL, "/"), r.schedule.ID)
+}
+
+// lintWriters write the report in each --format
+var lintWriters = map[string]func(out io.Writer, results []lintResult) error{
+	"text":  writeLintText,
+	"json":  writeLintJSON,
+	"sarif": writeLintSARIF,
+}
+
+// runLint checks the schedules with the lint rules. The exit code is 1 when an error or
+// a warning is found, notes alone don't fail.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var projects projectFlags
+	projects.register(fs)
+	format := fs.String("format", "text", "output format: text, json or sarif")
+	output := fs.String("output", "", "write the report to this file instead of the standard output")
+	disable := fs.String("disable", "", "comma-separated IDs of rules to turn off, on top of the config file")
+	inactiveDays := fs.Int("inactive-days", 0, fmt.Sprintf("days before an inactive schedule is flagged (default %d)", lint.DefaultInactiveDays))
+	minInterval := fs.Int("min-interval", 0, fmt.Sprintf("minutes, crons running more often are flagged (default %d)", lint.DefaultMinIntervalMinutes))
+	listRules := fs.Bool("rules", false, "list the rules and exit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *listRules {
+		for _, rule := range lint.Rules {
+			fmt.Fprintf(stdout, "%-20s %-8s %s\n", rule.ID, rule.Severity, rule.Description)
+		}
+		return 0
+	}
+
+	write, ok := lintWriters[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown format %q, use text, json or sarif\n", *format)
+		return 2
+	}
+	var disabled []string
+	for _, id := range strings.Split(*disable, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if _, ok := lint.RuleByID(id); !ok {
+			fmt.Fprintf(stderr, "Error: unknown rule %q, see glcron lint --rules\n", id)
+			return 2
+		}
+		disabled = append(disabled, id)
+	}
+
+	gitlabService, configs, err := projects.openProjects()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer projects.closeProjects(stderr)
+
+	// Flags override the settings of the config file
+	var lintConfig models.LintConfig
+	if projects.configFile.Lint != nil {
+		lintConfig = *projects.configFile.Lint
+	}
+	lintConfig.Disabled = append(append([]string(nil), lintConfig.Disabled...), disabled...)
+	if *inactiveDays > 0 {
+		lintConfig.InactiveDays = *inactiveDays
+	}
+	if *minInterval > 0 {
+		lintConfig.MinIntervalMinutes = *minInterval
+	}
+	opts := lint.NewOptions(&lintConfig)
+
+	exitCode := 0
+	now := time.Now()
+	results := []lintResult{}
+	for i := range configs {
+		config := configs[i]
+		if err := gitlabService.SetConfig(&config); err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", config.Name, err)
+			exitCode = 1
+			continue
+		}
+		schedules, err := gitlabService.GetSchedules()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", config.Name, err)
+			exitCode = 1
+			continue
+		}
+
+		// The other rules still run when the refs can't be checked
+		checks, err := gitlabService.CheckScheduleRefs(schedules)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", config.Name, err)
+			exitCode = 1
+		}
+		for j := range checks {
+			schedules[j].RefCheck = &checks[j]
+		}
+
+		byID := make(map[int]models.Schedule, len(schedules))
+		for _, s := range schedules {
+			byID[s.ID] = s
+		}
+		for _, finding := range lint.Run(schedules, opts, now) {
+			results = append(results, lintResult{project: config, schedule: byID[finding.ScheduleID], finding: finding})
+			if finding.Severity != lint.SeverityNote {
+				exitCode = 1
+			}
+		}
+	}
+
+	out := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to create %s: %v\n", *output, err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := write(out, results); err != nil {
+		fmt.Fprintf(stderr, "Error: failed to write report: %v\n", err)
+		return 1
+	}
+	return exitCode
+}
+
+// writeLintText writes one line per finding and a summary
+func writeLintText(out io.Writer, results []lintResult) error {
+	flagged := make(map[string]bool)
+	for _, r := range results {
+		flagged[fmt.Sprintf("%s#%d", r.project.ProjectURL, r.schedule.ID)] = true
+		if _, err := fmt.Fprintf(out, "%s: #%d %s: %s [%s] %s\n",
+			r.project.Name, r.schedule.ID, r.schedule.Description, r.finding.Severity, r.finding.Rule, r.finding.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "%d finding(s) in %d schedule(s)\n", len(results), len(flagged))
+	return err
+}
+
+// lintJSONResult is a finding of the JSON report
+type lintJSONResult struct {
+	Project     string        `json:"project"`
+	ProjectURL  string        `json:"project_url"`
+	ScheduleID  int           `json:"schedule_id"`
+	Description string        `json:"description"`
+	Ref         string        `json:"ref"`
+	Rule        string        `json:"rule"`
+	Severity    lint.Severity `json:"severity"`
+	Message     string        `json:"message"`
+	URL         string        `json:"url"`
+}
+
+// writeLintJSON writes the findings as a JSON array
+func writeLintJSON(out io.Writer, results []lintResult) error {
+	rows := make([]lintJSONResult, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, lintJSONResult{
+			Project:     r.project.Name,
+			ProjectURL:  r.project.ProjectURL,
+			ScheduleID:  r.schedule.ID,
+			Description: r.schedule.Description,
+			Ref:         r.schedule.Ref,
+			Rule:        r.finding.Rule,
+			Severity:    r.finding.Severity,
+			Message:     r.finding.Message,
+			URL:         r.scheduleURL(),
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+// SARIF 2.1.0, the parts glcron fills in
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+	sarifConfiguration struct {
+		Level lint.Severity `json:"level"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     lint.Severity   `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifLogicalLocation struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+// writeLintSARIF writes the findings as a SARIF log, for code scanning dashboards.
+// Schedules aren't files, their location is the URL of their edit page.
+func writeLintSARIF(out io.Writer, results []lintResult) error {
+	driver := sarifDriver{Name: "glcron", InformationURI: "https://github.com/nikitasova/glcron"}
+	ruleIndex := make(map[string]int, len(lint.Rules))
+	for i, rule := range lint.Rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: rule.Severity},
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, r := range results {
+		name := r.schedule.Description
+		if name == "" {
+			name = fmt.Sprintf("#%d", r.schedule.ID)
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    r.finding.Rule,
+			RuleIndex: ruleIndex[r.finding.Rule],
+			Level:     r.finding.Severity,
+			Message:   sarifMessage{Text: r.finding.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: r.scheduleURL()}},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               name,
+					FullyQualifiedName: fmt.Sprintf("%s/pipeline_schedules/%d", r.project.Name, r.schedule.ID),
+					Kind:               "resource",
+				}},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// reliabilityRow is a schedule of the reliability report
+type reliabilityRow struct {
+	project     string
+	schedule    models.Schedule
+	reliability *models.ScheduleReliability
+}
+
+// reliabilitySorts orders the report rows, the least reliable first by default
+var reliabilitySorts = map[string]func(a, b reliabilityRow) bool{
+	"rate": func(a, b reliabilityRow) bool {
+		return models.LessReliable(a.reliability, b.reliability)
+	},
+	"p95": func(a, b reliabilityRow) bool {
+		return a.reliability.P95Duration > b.reliability.P95Duration
+	},
+	"failures": func(a, b reliabilityRow) bool {
+		return a.reliability.ConsecutiveFailures > b.reliability.ConsecutiveFailures
+	},
+	"description": func(a, b reliabilityRow) bool {
+		return strings.ToLower(a.schedule.Description) < strings.ToLower(b.schedule.Description)
+	},
+}
+
+func runReport(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "reliability" {
+		fmt.Fprintln(stderr, "Usage: glcron report reliability [flags]")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("report reliability", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var projects projectFlags
+	projects.register(fs)
+	runs := fs.Int("runs", services.ReliabilityRuns, "number of recent runs of each schedule to look at")
+	sortBy := fs.String("sort", "rate", "order of the rows: rate, p95, failures or description")
+	output := fs.String("output", "", "write the CSV to this file instead of the standard output")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	less, ok := reliabilitySorts[*sortBy]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown sort %q, use rate, p95, failures or description\n", *sortBy)
+		return 2
+	}
+	if *runs <= 0 || *runs > 100 {
+		fmt.Fprintln(stderr, "Error: --runs must be between 1 and 100")
+		return 2
+	}
+
+	gitlabService, configs, err := projects.openProjects()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer projects.closeProjects(stderr)
+
+	exitCode := 0
+	var rows []reliabilityRow
+	for i := range configs {
+		config := configs[i]
+		if err := gitlabService.SetConfig(&config); err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", config.Name, err)
+			exitCode = 1
+			continue
+		}
+		schedules, err := gitlabService.GetSchedules()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", config.Name, err)
+			exitCode = 1
+			continue
+		}
+		for _, schedule := range schedules {
+			reliability, err := gitlabService.GetScheduleReliability(schedule.ID, *runs)
+			if err != nil {
+				fmt.Fprintf(stderr, "Error: %s: %s: %v\n", config.Name, schedule.Description, err)
+				exitCode = 1
+				continue
+			}
+			rows = append(rows, reliabilityRow{project: config.Name, schedule: schedule, reliability: reliability})
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return less(rows[i], rows[j])
+	})
+
+	out := stdout
+	var file *os.File
+	if *output != "" {
+		file, err = os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to create %s: %v\n", *output, err)
+			return 1
+		}
+		out = file
+	}
+
+	err = writeReliabilityCSV(out, rows)
+	// Closing flushes the file, a failure loses the report as well
+	if file != nil {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to write report: %v\n", err)
+		return 1
+	}
+	return exitCode
+}
+
+// writeReliabilityCSV writes the report, durations in seconds and the success rate between 0 and 1
+func writeReliabilityCSV(out io.Writer, rows []reliabilityRow) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{
+		"project", "schedule_id", "description", "ref", "cron", "active",
+		"runs", "succeeded", "failed", "success_rate",
+		"mean_duration", "p95_duration", "consecutive_failures",
+		"failing_job", "failing_job_failures",
+	})
+
+	for _, row := range rows {
+		r := row.reliability
+		successRate := ""
+		if rate := r.SuccessRate(); rate >= 0 {
+			successRate = strconv.FormatFloat(rate, 'f', 3, 64)
+		}
+		_ = w.Write([]string{
+			row.project,
+			strconv.Itoa(row.schedule.ID),
+			row.schedule.Description,
+			row.schedule.Ref,
+			row.schedule.Cron,
+			strconv.FormatBool(row.schedule.Active),
+			strconv.Itoa(r.Runs),
+			strconv.Itoa(r.Succeeded),
+			strconv.Itoa(r.Failed),
+			successRate,
+			strconv.FormatFloat(r.MeanDuration, 'f', 0, 64),
+			strconv.FormatFloat(r.P95Duration, 'f', 0, 64),
+			strconv.Itoa(r.ConsecutiveFailures),
+			r.FailingJob,
+			strconv.Itoa(r.FailingJobCount),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,203 @@
+package lint
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the shorthands GitLab accepts for common crons
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// cronSchedule holds the minutes, hours, days, months and weekdays a cron runs at as bit sets
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// A day matches either field when both are restricted, as in crontab
+	daysRestricted, weekdaysRestricted bool
+}
+
+// parseCron parses a five-field cron expression or one of its macros
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var c cronSchedule
+	var err error
+	if c.minutes, _, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute %q: %v", fields[0], err)
+	}
+	if c.hours, _, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour %q: %v", fields[1], err)
+	}
+	if c.days, c.daysRestricted, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day %q: %v", fields[2], err)
+	}
+	if c.months, _, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month %q: %v", fields[3], err)
+	}
+	if c.weekdays, c.weekdaysRestricted, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid weekday %q: %v", fields[4], err)
+	}
+	// 7 is another name for Sunday
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+	return &c, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps into a bit set.
+// Returns false when the field is a wildcard.
+func parseCronField(field string, min, max int, names []string) (uint64, bool, error) {
+	if field == "*" || field == "?" {
+		return cronRange(min, max, 1), false, nil
+	}
+
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, true, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			rangePart = part[:i]
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], min, max, names); err != nil {
+				return 0, true, err
+			}
+			if high, err = cronValue(bounds[1], min, max, names); err != nil {
+				return 0, true, err
+			}
+			if low > high {
+				return 0, true, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := cronValue(rangePart, min, max, names)
+			if err != nil {
+				return 0, true, err
+			}
+			low = value
+			// "5/15" runs from 5 to the end of the field
+			if step == 1 {
+				high = value
+			}
+		}
+		set |= cronRange(low, high, step)
+	}
+	return set, true, nil
+}
+
+// cronValue parses a number or a month or weekday name
+func cronValue(s string, min, max int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return i + min, nil
+		}
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < min || value > max {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return value, nil
+}
+
+func cronRange(low, high, step int) uint64 {
+	var set uint64
+	for v := low; v <= high; v += step {
+		set |= 1 << uint(v)
+	}
+	return set
+}
+
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	if c.months&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	day := c.days&(1<<uint(t.Day())) != 0
+	weekday := c.weekdays&(1<<uint(t.Weekday())) != 0
+	if c.daysRestricted && c.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+// minInterval returns the shortest time between two runs of the cron, 0 if it runs at most
+// once over two years. Timezones and daylight saving time are left out.
+func (c *cronSchedule) minInterval() time.Duration {
+	// Minutes of the day the cron runs at, the same on every day it runs
+	var times []int
+	for h := 0; h < 24; h++ {
+		if c.hours&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if c.minutes&(1<<uint(m)) != 0 {
+				times = append(times, h*60+m)
+			}
+		}
+	}
+	if len(times) == 0 {
+		return 0
+	}
+
+	shortest := 0
+	for i := 1; i < len(times); i++ {
+		if gap := times[i] - times[i-1]; shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+	}
+
+	// From the last run of a day to the first run of the next day the cron runs on,
+	// over two years to go through a leap day and every weekday of each date
+	minutesPerDay := 24 * 60
+	lastDay := -1
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 731; day++ {
+		if !c.matchesDay(start.AddDate(0, 0, day)) {
+			continue
+		}
+		if lastDay >= 0 {
+			gap := (day-lastDay)*minutesPerDay - times[len(times)-1] + times[0]
+			if shortest == 0 || gap < shortest {
+				shortest = gap
+			}
+		}
+		lastDay = day
+	}
+
+	return time.Duration(shortest) * time.Minute
+}
+
+// runsPerDay counts how many times a day the cron runs on the days it runs
+func (c *cronSchedule) runsPerDay() int {
+	return bits.OnesCount64(c.hours) * bits.OnesCount64(c.minutes)
+}
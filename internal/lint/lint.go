@@ -0,0 +1,132 @@
+// Package lint checks pipeline schedules for mistakes and risky settings
+package lint
+
+import (
+	"glcron/internal/models"
+	"sort"
+	"time"
+)
+
+// Severity tells how bad a finding is, with the level names of SARIF
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The schedule doesn't run, or not as expected
+	SeverityWarning Severity = "warning" // The schedule runs but something is risky
+	SeverityNote    Severity = "note"    // Housekeeping
+)
+
+// Rank orders severities, the worst first
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Defaults of the tunable rules, see models.LintConfig
+const (
+	DefaultInactiveDays       = 30
+	DefaultMinIntervalMinutes = 15
+)
+
+// Finding is a problem a rule found in a schedule
+type Finding struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	ScheduleID int      `json:"schedule_id"`
+	Message    string   `json:"message"`
+}
+
+// Options are the settings of the rules
+type Options struct {
+	Disabled     map[string]bool
+	InactiveDays int
+	MinInterval  time.Duration
+}
+
+// NewOptions applies the defaults to the lint settings of the config file, which may be nil
+func NewOptions(config *models.LintConfig) Options {
+	opts := Options{
+		Disabled:     make(map[string]bool),
+		InactiveDays: DefaultInactiveDays,
+		MinInterval:  DefaultMinIntervalMinutes * time.Minute,
+	}
+	if config == nil {
+		return opts
+	}
+	for _, id := range config.Disabled {
+		opts.Disabled[id] = true
+	}
+	if config.InactiveDays > 0 {
+		opts.InactiveDays = config.InactiveDays
+	}
+	if config.MinIntervalMinutes > 0 {
+		opts.MinInterval = time.Duration(config.MinIntervalMinutes) * time.Minute
+	}
+	return opts
+}
+
+// reportFunc records a finding of the running rule on a schedule
+type reportFunc func(schedule models.Schedule, message string)
+
+// Rule checks the schedules of a project
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	check       func(schedules []models.Schedule, opts Options, now time.Time, report reportFunc)
+}
+
+// RuleByID returns the rule with the given ID
+func RuleByID(id string) (Rule, bool) {
+	for _, rule := range Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Run checks the schedules with every enabled rule. Findings are in the order of the
+// schedules, then of the rules.
+func Run(schedules []models.Schedule, opts Options, now time.Time) []Finding {
+	var findings []Finding
+	for _, rule := range Rules {
+		if opts.Disabled[rule.ID] {
+			continue
+		}
+		rule.check(schedules, opts, now, func(schedule models.Schedule, message string) {
+			findings = append(findings, Finding{
+				Rule:       rule.ID,
+				Severity:   rule.Severity,
+				ScheduleID: schedule.ID,
+				Message:    message,
+			})
+		})
+	}
+
+	position := make(map[int]int, len(schedules))
+	for i, s := range schedules {
+		position[s.ID] = i
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return position[findings[i].ScheduleID] < position[findings[j].ScheduleID]
+	})
+	return findings
+}
+
+// Worst returns the most severe of the findings, nil if there are none
+func Worst(findings []Finding) *Finding {
+	var worst *Finding
+	for i := range findings {
+		if worst == nil || findings[i].Severity.Rank() < worst.Severity.Rank() {
+			worst = &findings[i]
+		}
+	}
+	return worst
+}
@@ -0,0 +1,202 @@
+package lint
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Rules lists every rule, in the order their findings are reported for a schedule
+var Rules = []Rule{
+	{
+		ID:          "ref-missing",
+		Description: "The branch or tag of the schedule no longer exists, GitLab fails to run it",
+		Severity:    SeverityError,
+		check:       checkRefMissing,
+	},
+	{
+		ID:          "owner-left",
+		Description: "The schedule has no owner, or its owner is blocked, GitLab doesn't run it",
+		Severity:    SeverityError,
+		check:       checkOwnerLeft,
+	},
+	{
+		ID:          "ref-unprotected",
+		Description: "The ref is not protected, pipelines don't get the protected variables of the project",
+		Severity:    SeverityWarning,
+		check:       checkRefUnprotected,
+	},
+	{
+		ID:          "cron-too-frequent",
+		Description: "The cron runs more often than the shortest interval allowed",
+		Severity:    SeverityWarning,
+		check:       checkCronTooFrequent,
+	},
+	{
+		ID:          "duplicate-cron",
+		Description: "Another active schedule runs the same cron on the same ref",
+		Severity:    SeverityWarning,
+		check:       checkDuplicateCron,
+	},
+	{
+		ID:          "secret-variable",
+		Description: "A variable of the schedule looks like a secret, readable by anyone who can edit the schedule",
+		Severity:    SeverityWarning,
+		check:       checkSecretVariables,
+	},
+	{
+		ID:          "ref-unprotected-unreferenced",
+		Description: "The ref is not protected, the protected variables of the project aren't referenced by the schedule or .gitlab-ci.yml but jobs may still read them",
+		Severity:    SeverityNote,
+		check:       checkRefUnprotectedUnreferenced,
+	},
+	{
+		ID:          "inactive",
+		Description: "The schedule has been inactive for a long time",
+		Severity:    SeverityNote,
+		check:       checkInactive,
+	},
+	{
+		ID:          "missing-description",
+		Description: "The schedule has no description",
+		Severity:    SeverityNote,
+		check:       checkMissingDescription,
+	},
+}
+
+// Rules on the ref need the schedules' RefCheck, schedules without one are skipped
+
+func checkRefMissing(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if s.RefCheck != nil && s.RefCheck.Missing {
+			report(s, s.RefCheck.Problem())
+		}
+	}
+}
+
+func checkRefUnprotected(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if s.RefCheck != nil && s.RefCheck.Unprotected {
+			report(s, s.RefCheck.Problem())
+		}
+	}
+}
+
+func checkRefUnprotectedUnreferenced(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if s.RefCheck != nil && s.RefCheck.MayMissVariables {
+			report(s, s.RefCheck.Problem())
+		}
+	}
+}
+
+func checkOwnerLeft(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		switch {
+		case s.Owner.ID == 0:
+			report(s, "no owner, take ownership so it runs again")
+		case s.Owner.HasLeft():
+			report(s, fmt.Sprintf("owner @%s is %s, take ownership so it runs again", s.Owner.Username, s.Owner.State))
+		}
+	}
+}
+
+func checkCronTooFrequent(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if !s.Active {
+			continue
+		}
+		cron, err := parseCron(s.Cron)
+		if err != nil {
+			// GitLab validates crons, glcron may just not understand this one
+			continue
+		}
+		if interval := cron.minInterval(); interval > 0 && interval < opts.MinInterval {
+			report(s, fmt.Sprintf("runs as often as every %s (%d runs a day), more often than every %s",
+				formatInterval(interval), cron.runsPerDay(), formatInterval(opts.MinInterval)))
+		}
+	}
+}
+
+func checkDuplicateCron(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	type key struct{ cron, timezone, ref string }
+	groups := make(map[key][]models.Schedule)
+	for _, s := range schedules {
+		if s.Active {
+			k := key{strings.Join(strings.Fields(s.Cron), " "), s.CronTimezone, s.Ref}
+			groups[k] = append(groups[k], s)
+		}
+	}
+
+	for _, s := range schedules {
+		if !s.Active {
+			continue
+		}
+		group := groups[key{strings.Join(strings.Fields(s.Cron), " "), s.CronTimezone, s.Ref}]
+		var others []string
+		for _, other := range group {
+			if other.ID != s.ID {
+				others = append(others, fmt.Sprintf("#%d", other.ID))
+			}
+		}
+		if len(others) > 0 {
+			report(s, fmt.Sprintf("runs %q on %s like %s", s.Cron, s.Ref, strings.Join(others, ", ")))
+		}
+	}
+}
+
+var (
+	// secretKeyPattern matches variable names that usually hold credentials
+	secretKeyPattern = regexp.MustCompile(`(?i)(TOKEN|SECRET|PASSWORD|PASSWD|PRIVATE_KEY|API_KEY|ACCESS_KEY|CREDENTIAL)`)
+	// secretValuePattern matches well-known token formats
+	secretValuePattern = regexp.MustCompile(`^(glpat-|gldt-|glrt-|glptt-|ghp_|github_pat_|xox[abprs]-|sk_live_)|AKIA[0-9A-Z]{16}|-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+)
+
+func checkSecretVariables(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		for _, v := range s.Variables {
+			value := strings.TrimSpace(v.Value)
+			// A reference to another variable is fine
+			if value == "" || strings.HasPrefix(value, "$") {
+				continue
+			}
+			if secretValuePattern.MatchString(value) || secretKeyPattern.MatchString(v.Key) {
+				report(s, fmt.Sprintf("variable %s looks like a secret, use a masked CI/CD variable instead", v.Key))
+			}
+		}
+	}
+}
+
+func checkInactive(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if s.Active || s.UpdatedAt.IsZero() {
+			continue
+		}
+		days := int(now.Sub(s.UpdatedAt).Hours() / 24)
+		if days > opts.InactiveDays {
+			report(s, fmt.Sprintf("inactive for %d days, since %s", days, s.UpdatedAt.Format("2006-01-02")))
+		}
+	}
+}
+
+func checkMissingDescription(schedules []models.Schedule, opts Options, now time.Time, report reportFunc) {
+	for _, s := range schedules {
+		if strings.TrimSpace(s.Description) == "" {
+			report(s, "no description")
+		}
+	}
+}
+
+// formatInterval formats a cron interval like 5m, 2h or 1h30m
+func formatInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
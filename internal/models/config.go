@@ -1,15 +1,145 @@
 package models
 
-// Config represents a GitLab project configuration
+import "time"
+
+// Config represents a GitLab project configuration.
+// Token, BaseURL, AuthType and OAuth are filled in from the referenced Instance when loaded,
+// they are only stored on the config itself in files written before instance profiles.
 type Config struct {
-	Name       string `json:"name"`        // Custom display name
-	ProjectURL string `json:"project_url"` // GitLab project URL (https://gitlab.company.com/group/project)
-	Token      string `json:"token"`       // GitLab personal access token
-	ProjectID  int    `json:"project_id"`  // GitLab project ID (extracted from API)
-	BaseURL    string `json:"base_url"`    // Base GitLab API URL
+	Name       string `json:"name"`               // Custom display name
+	ProjectURL string `json:"project_url"`        // GitLab project URL (https://gitlab.company.com/group/project)
+	Instance   string `json:"instance,omitempty"` // Name of the Instance holding the credentials
+	Token      string `json:"token,omitempty"`    // GitLab personal access token
+	ProjectID  int    `json:"project_id"`         // GitLab project ID (extracted from API)
+	BaseURL    string `json:"base_url,omitempty"` // Base GitLab API URL
+
+	AuthType string            `json:"auth_type,omitempty"` // "token" (default) or "oauth"
+	OAuth    *OAuthCredentials `json:"oauth,omitempty"`     // Set when AuthType is "oauth"
+
+	Group string   `json:"group,omitempty"` // Folder shown in the config list, empty for ungrouped
+	Tags  []string `json:"tags,omitempty"`  // Free-form labels used for filtering
+
+	LastOpenedAt *time.Time `json:"last_opened_at,omitempty"` // Used for "recently used" ordering
+
+	RefreshInterval int `json:"refresh_interval,omitempty"` // Seconds between refreshes of running pipelines, 0 for the default
+}
+
+// HasTag returns true if the config is labelled with the given tag
+func (c *Config) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Authentication methods for a config
+const (
+	AuthTypeToken = "token"
+	AuthTypeOAuth = "oauth"
+)
+
+// UsesOAuth returns true if the config authenticates with OAuth instead of a personal access token
+func (c *Config) UsesOAuth() bool {
+	return c.AuthType == AuthTypeOAuth
+}
+
+// OAuthCredentials holds tokens obtained through the OAuth device authorization flow
+type OAuthCredentials struct {
+	ApplicationID string    `json:"application_id"` // OAuth application ID registered on the GitLab instance
+	AccessToken   string    `json:"access_token"`
+	RefreshToken  string    `json:"refresh_token"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// DeviceAuthorization is the response of the OAuth device authorization endpoint
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"` // Seconds until the device code expires
+	Interval                int    `json:"interval"`   // Minimum seconds between token polls
 }
 
 // ConfigFile represents the configuration file structure
 type ConfigFile struct {
-	Configs []Config `json:"configs"`
+	Version         int         `json:"version"` // Schema version, see services.CurrentConfigVersion
+	Instances       []Instance  `json:"instances,omitempty"`
+	Configs         []Config    `json:"configs"`
+	CollapsedGroups []string    `json:"collapsed_groups,omitempty"` // Groups folded in the config list
+	WatchPipelines  bool        `json:"watch_pipelines,omitempty"`  // Follow pipelines started from glcron and notify when they finish
+	Lint            *LintConfig `json:"lint,omitempty"`             // Settings of the schedule lint rules
+	// Tokens expiring within this many days are flagged, 14 by default
+	TokenExpiryWarningDays int `json:"token_expiry_warning_days,omitempty"`
+}
+
+// LintConfig tunes the schedule lint rules, zero values keep the defaults
+type LintConfig struct {
+	Disabled           []string `json:"disabled,omitempty"`             // IDs of the rules turned off
+	InactiveDays       int      `json:"inactive_days,omitempty"`        // Days before an inactive schedule is flagged, 30 by default
+	MinIntervalMinutes int      `json:"min_interval_minutes,omitempty"` // Crons running more often are flagged, 15 by default
+}
+
+// Instance is a GitLab host with the credentials shared by all project configs on it
+type Instance struct {
+	Name     string            `json:"name"` // Unique, referenced by Config.Instance
+	BaseURL  string            `json:"base_url"`
+	Token    string            `json:"token,omitempty"`
+	AuthType string            `json:"auth_type,omitempty"`
+	OAuth    *OAuthCredentials `json:"oauth,omitempty"`
+}
+
+// GitLab project access levels
+const (
+	AccessLevelNone       = 0
+	AccessLevelGuest      = 10
+	AccessLevelReporter   = 20
+	AccessLevelDeveloper  = 30
+	AccessLevelMaintainer = 40
+	AccessLevelOwner      = 50
+)
+
+// AccessLevelName returns the GitLab role name for an access level
+func AccessLevelName(level int) string {
+	switch {
+	case level >= AccessLevelOwner:
+		return "Owner"
+	case level >= AccessLevelMaintainer:
+		return "Maintainer"
+	case level >= AccessLevelDeveloper:
+		return "Developer"
+	case level >= AccessLevelReporter:
+		return "Reporter"
+	case level >= AccessLevelGuest:
+		return "Guest"
+	default:
+		return "No access"
+	}
+}
+
+// TokenInfo represents the token returned by the personal access token self endpoint
+type TokenInfo struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"` // YYYY-MM-DD, empty if the token never expires
+	Active    bool     `json:"active"`
+	Revoked   bool     `json:"revoked"`
+}
+
+// TokenHealth summarizes what a config's token is allowed to do
+type TokenHealth struct {
+	TokenName   string
+	Scopes      []string   // Nil if the instance doesn't expose token details
+	ExpiresAt   *time.Time // Nil if the token never expires or expiry is unknown
+	Username    string
+	AccessLevel int
+	Warnings    []string
+}
+
+// HasWarnings returns true if any problem was detected with the token
+func (h *TokenHealth) HasWarnings() bool {
+	return h != nil && len(h.Warnings) > 0
 }
@@ -0,0 +1,19 @@
+package models
+
+// Group represents a GitLab group
+type Group struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	FullPath string `json:"full_path"`
+	WebURL   string `json:"web_url"`
+}
+
+// Project represents a GitLab project as listed by the projects API
+type Project struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	Description       string `json:"description"`
+	WebURL            string `json:"web_url"`
+	Archived          bool   `json:"archived"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Schedule represents a GitLab pipeline schedule
 type Schedule struct {
@@ -16,6 +20,83 @@ type Schedule struct {
 	Owner        Owner      `json:"owner"`
 	LastPipeline *Pipeline  `json:"last_pipeline"`
 	Variables    []Variable `json:"variables"`
+	// Statistics over the recent runs
+	Reliability *ScheduleReliability `json:"-"` // Fetched separately
+	// Problems of the target branch or tag
+	RefCheck *RefCheck `json:"-"` // Fetched separately
+}
+
+// RefCheck tells whether the branch or tag of a schedule can still run it
+type RefCheck struct {
+	ScheduleID  int
+	Ref         string
+	Missing     bool // No branch or tag has this name, GitLab fails to run the schedule
+	Unprotected bool // The pipelines won't get protected variables the schedule or CI config use
+	// The ref is unprotected and the project has protected variables, which neither the schedule
+	// nor the CI config reference. Jobs may still read them, e.g. from included files or tools.
+	MayMissVariables bool
+	// Protected CI/CD variables used by the schedule when Unprotected, all those of the
+	// project when MayMissVariables
+	ProtectedVariables []string
+}
+
+// Problem describes what is wrong with the ref, "" if nothing is
+func (c *RefCheck) Problem() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Missing:
+		return fmt.Sprintf("%s no longer exists, GitLab can't run the schedule", c.Ref)
+	case c.Unprotected:
+		return fmt.Sprintf("%s is not protected, pipelines won't get the protected variable(s) %s", c.Ref, strings.Join(c.ProtectedVariables, ", "))
+	case c.MayMissVariables:
+		return fmt.Sprintf("%s is not protected, pipelines won't get the %d protected variable(s) of the project. "+
+			"Approximation: neither the schedule nor .gitlab-ci.yml reference them, jobs may still read them", c.Ref, len(c.ProtectedVariables))
+	}
+	return ""
+}
+
+// ScheduleReliability summarizes the recent runs of a pipeline schedule.
+// Only successful and failed runs count, canceled and running ones are left out.
+type ScheduleReliability struct {
+	ScheduleID          int
+	Runs                int // Pipelines looked at
+	Succeeded           int
+	Failed              int
+	MeanDuration        float64 // Seconds
+	P95Duration         float64 // Seconds
+	ConsecutiveFailures int     // Failed runs since the last successful one
+	FailingJob          string  // Job that failed most often, empty if none did
+	FailingJobCount     int
+}
+
+// Finished returns the number of runs the statistics are computed from
+func (r *ScheduleReliability) Finished() int {
+	return r.Succeeded + r.Failed
+}
+
+// SuccessRate returns the share of successful runs between 0 and 1, or -1 without finished runs
+func (r *ScheduleReliability) SuccessRate() float64 {
+	if r.Finished() == 0 {
+		return -1
+	}
+	return float64(r.Succeeded) / float64(r.Finished())
+}
+
+// LessReliable reports whether a is less reliable than b, ties go to the longest failure streak.
+// Schedules without statistics or finished runs come last.
+func LessReliable(a, b *ScheduleReliability) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	rateA, rateB := a.SuccessRate(), b.SuccessRate()
+	if (rateA < 0) != (rateB < 0) {
+		return rateB < 0
+	}
+	if rateA != rateB {
+		return rateA < rateB
+	}
+	return a.ConsecutiveFailures > b.ConsecutiveFailures
 }
 
 // Owner represents the schedule owner
@@ -25,6 +106,12 @@ type Owner struct {
 	Name      string `json:"name"`
 	AvatarURL string `json:"avatar_url"`
 	WebURL    string `json:"web_url"`
+	State     string `json:"state"` // "active", "blocked", "deactivated"...
+}
+
+// HasLeft returns true if the schedule has no owner or the owner can no longer run it
+func (o Owner) HasLeft() bool {
+	return o.ID == 0 || (o.State != "" && o.State != "active")
 }
 
 // User represents the current GitLab user
@@ -49,11 +136,35 @@ type Pipeline struct {
 	Name      string     `json:"name"`   // Pipeline name (usually commit title)
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
-	User      *User      `json:"user"`
+	// Only set when fetching a single pipeline
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Duration   int        `json:"duration"` // Seconds
+	User       *User      `json:"user"`
 	// Upstream pipeline info (for child pipelines)
 	UpstreamPipeline *UpstreamPipeline `json:"-"` // Fetched separately
 }
 
+// IsRunning returns true while the pipeline can still change
+func (p Pipeline) IsRunning() bool {
+	switch p.Status {
+	case "created", "waiting_for_resource", "preparing", "pending", "running":
+		return true
+	default:
+		return false
+	}
+}
+
+// DurationSeconds returns how long a finished pipeline ran, 0 while it runs or when the
+// duration is unknown. Pipelines listed for a schedule come without it, it has to be fetched
+// for each of them.
+func (p Pipeline) DurationSeconds() float64 {
+	if p.IsRunning() {
+		return 0
+	}
+	return float64(p.Duration)
+}
+
 // UpstreamPipeline represents info about the pipeline that triggered this one
 type UpstreamPipeline struct {
 	ID          int    `json:"id"`
@@ -67,19 +178,24 @@ type PipelineBridge struct {
 	Name               string                  `json:"name"`
 	Stage              string                  `json:"stage"`
 	Status             string                  `json:"status"`
+	StartedAt          *time.Time              `json:"started_at"`
+	Duration           float64                 `json:"duration"`
+	WebURL             string                  `json:"web_url"`
 	DownstreamPipeline *DownstreamPipelineInfo `json:"downstream_pipeline"`
 	UpstreamPipeline   *UpstreamPipelineInfo   `json:"upstream_pipeline"`
 }
 
 // DownstreamPipelineInfo contains info about a triggered downstream pipeline
 type DownstreamPipelineInfo struct {
-	ID        int            `json:"id"`
-	Status    string         `json:"status"`
-	ProjectID int            `json:"project_id"`
-	Project   *ProjectInfo   `json:"project"`
+	ID        int          `json:"id"`
+	Status    string       `json:"status"`
+	Ref       string       `json:"ref"`
+	WebURL    string       `json:"web_url"`
+	ProjectID int          `json:"project_id"`
+	Project   *ProjectInfo `json:"project"`
 }
 
-// UpstreamPipelineInfo contains info about the upstream triggering pipeline  
+// UpstreamPipelineInfo contains info about the upstream triggering pipeline
 type UpstreamPipelineInfo struct {
 	ID        int          `json:"id"`
 	Status    string       `json:"status"`
@@ -104,13 +220,87 @@ type PipelineJob struct {
 	StartedAt *time.Time `json:"started_at"`
 	Duration  float64    `json:"duration"`
 	WebURL    string     `json:"web_url"`
+	Runner    *Runner    `json:"runner"` // Nil until the job is picked up
+	// Failures of jobs allowed to fail don't fail the pipeline
+	AllowFailure bool `json:"allow_failure"`
+	// Files kept by the job, the "archive" one holds the paths listed under artifacts:paths
+	Artifacts         []JobArtifact `json:"artifacts"`
+	ArtifactsExpireAt *time.Time    `json:"artifacts_expire_at"`
+}
+
+// JobArtifact is a file kept by a job: the archive, a report or the log
+type JobArtifact struct {
+	FileType   string `json:"file_type"` // "archive", "metadata", "trace", "junit", "cobertura"...
+	Size       int64  `json:"size"`
+	Filename   string `json:"filename"`
+	FileFormat string `json:"file_format"` // "zip", "gzip", "raw"
+}
+
+// ArchiveArtifact returns the artifacts archive of the job, nil if it has none
+func (j *PipelineJob) ArchiveArtifact() *JobArtifact {
+	for i := range j.Artifacts {
+		if j.Artifacts[i].FileType == "archive" {
+			return &j.Artifacts[i]
+		}
+	}
+	return nil
+}
+
+// ArtifactFile is a file or directory in an artifacts archive
+type ArtifactFile struct {
+	Path  string // Slash separated, directories end with "/"
+	Size  int64
+	IsDir bool
+}
+
+// Runner is the GitLab runner that executed a job
+type Runner struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	IsShared    bool   `json:"is_shared"`
+}
+
+// PipelineFilter narrows a pipeline listing, empty fields match every pipeline
+type PipelineFilter struct {
+	Ref           string
+	Status        string     // "running", "success", "failed"...
+	Source        string     // "schedule", "web", "push", "merge_request_event"...
+	Username      string     // User who started the pipeline
+	UpdatedAfter  *time.Time // Range of the last update, not of the creation
+	UpdatedBefore *time.Time
+}
+
+// IsEmpty returns true if the filter matches every pipeline
+func (f PipelineFilter) IsEmpty() bool {
+	return f == PipelineFilter{}
+}
+
+// Matches returns true if the pipeline passes the filter, as the GitLab API applies it.
+// The pipeline needs its user, which only single pipelines come with.
+func (f PipelineFilter) Matches(p Pipeline) bool {
+	switch {
+	case f.Ref != "" && p.Ref != f.Ref,
+		f.Status != "" && p.Status != f.Status,
+		f.Source != "" && p.Source != f.Source,
+		f.Username != "" && (p.User == nil || p.User.Username != f.Username):
+		return false
+	}
+	if p.UpdatedAt != nil {
+		if f.UpdatedAfter != nil && p.UpdatedAt.Before(*f.UpdatedAfter) {
+			return false
+		}
+		if f.UpdatedBefore != nil && !p.UpdatedAt.Before(*f.UpdatedBefore) {
+			return false
+		}
+	}
+	return true
 }
 
 // PipelineWithJobs represents a pipeline with its jobs for display
 type PipelineWithJobs struct {
-	Pipeline           Pipeline
-	Jobs               []PipelineJob
-	Stages             []StageInfo
+	Pipeline            Pipeline
+	Jobs                []PipelineJob
+	Stages              []StageInfo
 	UpstreamProjectName string // Name of the project that triggered this pipeline
 }
 
@@ -122,8 +312,20 @@ type StageInfo struct {
 
 // PipelineCreateRequest represents a request to create a pipeline
 type PipelineCreateRequest struct {
-	Ref       string     `json:"ref"`
-	Variables []Variable `json:"variables,omitempty"`
+	Ref       string                 `json:"ref"`
+	Variables []Variable             `json:"variables,omitempty"`
+	Inputs    map[string]interface{} `json:"inputs,omitempty"` // spec:inputs values, typed as declared
+}
+
+// RunField is a pipeline input or a variable of .gitlab-ci.yml the run form asks for
+type RunField struct {
+	Name        string
+	Input       bool   // Declared in spec:inputs, a variable otherwise
+	Type        string // Type of an input: "string", "number", "boolean" or "array"
+	Description string
+	Value       string // Default value
+	Options     []string
+	Required    bool // Input without a default value
 }
 
 // Variable represents a pipeline schedule variable
@@ -140,6 +342,26 @@ type Branch struct {
 	Default   bool   `json:"default"`
 }
 
+// Tag represents a GitLab tag
+type Tag struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+}
+
+// Kinds of refs pipelines run on
+const (
+	RefKindBranch = "branch"
+	RefKindTag    = "tag"
+)
+
+// ResolvedRef is a branch, tag or commit SHA typed by the user, checked against the repository
+type ResolvedRef struct {
+	Name   string // Branch or tag to run on
+	Kind   string // RefKindBranch or RefKindTag
+	SHA    string // Commit the ref points to
+	Commit bool   // Typed as a commit SHA, Name is the ref pointing to it
+}
+
 // ScheduleCreateRequest represents request to create a schedule
 type ScheduleCreateRequest struct {
 	Description  string     `json:"description"`
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"archive/zip"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArtifactArchive is an artifacts archive downloaded to a temporary file for browsing
+type ArtifactArchive struct {
+	file *os.File
+	size int64
+}
+
+// GetArtifactsArchive downloads the artifacts archive of a job to a temporary file, for
+// browsing. Close removes the file.
+func (g *GitLabService) GetArtifactsArchive(jobID int) (*ArtifactArchive, error) {
+	file, err := os.CreateTemp("", "glcron-artifacts-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	archive := &ArtifactArchive{file: file}
+
+	if err := g.DownloadArtifacts(jobID, file); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		archive.Close()
+		return nil, fmt.Errorf("failed to read artifacts archive: %v", err)
+	}
+	archive.size = info.Size()
+	return archive, nil
+}
+
+// Close removes the temporary file of the archive
+func (a *ArtifactArchive) Close() error {
+	a.file.Close()
+	return os.Remove(a.file.Name())
+}
+
+// WriteTo copies the whole archive to w
+func (a *ArtifactArchive) WriteTo(w io.Writer) (int64, error) {
+	return io.Copy(w, io.NewSectionReader(a.file, 0, a.size))
+}
+
+// Files lists the files and directories of the archive, sorted by path
+func (a *ArtifactArchive) Files() ([]models.ArtifactFile, error) {
+	reader, err := zip.NewReader(a.file, a.size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts archive: %v", err)
+	}
+	return listArtifactFiles(reader), nil
+}
+
+// Extract writes a single file of the archive to w
+func (a *ArtifactArchive) Extract(path string, w io.Writer) error {
+	reader, err := zip.NewReader(a.file, a.size)
+	if err != nil {
+		return fmt.Errorf("failed to read artifacts archive: %v", err)
+	}
+	return extractArtifactFile(reader, path, w)
+}
+
+// DownloadArtifacts writes the artifacts archive of a job to w
+func (g *GitLabService) DownloadArtifacts(jobID int, w io.Writer) error {
+	return g.download(fmt.Sprintf("/api/v4/projects/%d/jobs/%d/artifacts", g.projectID, jobID), "artifacts", w)
+}
+
+// DownloadArtifactFile writes a single file of the artifacts archive of a job to w
+func (g *GitLabService) DownloadArtifactFile(jobID int, path string, w io.Writer) error {
+	var escaped []string
+	for _, part := range strings.Split(path, "/") {
+		escaped = append(escaped, url.PathEscape(part))
+	}
+	return g.download(fmt.Sprintf("/api/v4/projects/%d/jobs/%d/artifacts/%s", g.projectID, jobID, strings.Join(escaped, "/")), "artifact file", w)
+}
+
+// download streams the body of a GET request to w. It uses the download client, a large
+// archive may take longer than the timeout of API calls.
+func (g *GitLabService) download(path, what string, w io.Writer) error {
+	resp, err := g.doRequestWith(g.downloadClient, "GET", path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to download %s: %s - %s", what, resp.Status, string(body))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download %s: %v", what, err)
+	}
+	return nil
+}
+
+// listArtifactFiles lists the files and directories of a zip artifacts archive, sorted by path
+func listArtifactFiles(reader *zip.Reader) []models.ArtifactFile {
+	seen := make(map[string]bool)
+	var files []models.ArtifactFile
+	addDir := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			files = append(files, models.ArtifactFile{Path: dir, IsDir: true})
+		}
+	}
+
+	for _, f := range reader.File {
+		name := strings.TrimPrefix(f.Name, "./")
+		// Archives don't always hold entries for the parent directories
+		for i, r := range name {
+			if r == '/' && i < len(name)-1 {
+				addDir(name[:i+1])
+			}
+		}
+		if strings.HasSuffix(name, "/") {
+			addDir(name)
+			continue
+		}
+		files = append(files, models.ArtifactFile{Path: name, Size: int64(f.UncompressedSize64)})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// extractArtifactFile writes a single file of a zip artifacts archive to w
+func extractArtifactFile(reader *zip.Reader, path string, w io.Writer) error {
+	for _, f := range reader.File {
+		if strings.TrimPrefix(f.Name, "./") != path {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %v", path, err)
+		}
+		defer rc.Close()
+		if _, err := io.Copy(w, rc); err != nil {
+			return fmt.Errorf("failed to extract %s: %v", path, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not in the artifacts archive", path)
+}
+
+// CreateDownloadFile creates the local file a download is saved to, with its directory.
+// A leading "~/" stands for the home directory. An existing file is never overwritten, a
+// number is added to the name instead. It returns the path of the file created.
+func CreateDownloadFile(dest string) (*os.File, string, error) {
+	if strings.HasPrefix(dest, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find home directory: %v", err)
+		}
+		dest = filepath.Join(home, dest[2:])
+	}
+	dest = filepath.Clean(dest)
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	ext := filepath.Ext(dest)
+	base := strings.TrimSuffix(dest, ext)
+	path := dest
+	for n := 2; ; n++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			return file, path, nil
+		}
+		if !os.IsExist(err) {
+			return nil, "", fmt.Errorf("failed to create %s: %v", path, err)
+		}
+		path = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+}
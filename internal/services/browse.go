@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BrowsePageSize is the number of groups or projects fetched per page while browsing
+const BrowsePageSize = 50
+
+// ListGroups lists the groups the token's user is a member of, one page at a time.
+// Returns the next page number, or 0 on the last page.
+func (g *GitLabService) ListGroups(baseURL, token, search string, page int) ([]models.Group, int, error) {
+	params := url.Values{}
+	params.Set("order_by", "path")
+	params.Set("sort", "asc")
+	if search != "" {
+		params.Set("search", search)
+	}
+
+	var groups []models.Group
+	nextPage, err := g.listPage(baseURL, token, "/api/v4/groups", params, page, &groups)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list groups: %v", err)
+	}
+
+	return groups, nextPage, nil
+}
+
+// ListProjects lists the projects of a group including its subgroups, or all projects the
+// user is a member of when groupID is 0, one page at a time.
+// Returns the next page number, or 0 on the last page.
+func (g *GitLabService) ListProjects(baseURL, token string, groupID int, search string, page int) ([]models.Project, int, error) {
+	params := url.Values{}
+	params.Set("order_by", "path")
+	params.Set("sort", "asc")
+	params.Set("archived", "false")
+	if search != "" {
+		params.Set("search", search)
+	}
+
+	path := "/api/v4/projects"
+	if groupID > 0 {
+		path = fmt.Sprintf("/api/v4/groups/%d/projects", groupID)
+		params.Set("include_subgroups", "true")
+	} else {
+		params.Set("membership", "true")
+	}
+
+	var projects []models.Project
+	nextPage, err := g.listPage(baseURL, token, path, params, page, &projects)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list projects: %v", err)
+	}
+
+	return projects, nextPage, nil
+}
+
+// listPage fetches one page of a list endpoint on the given instance and decodes it into out
+func (g *GitLabService) listPage(baseURL, token, path string, params url.Values, page int, out interface{}) (int, error) {
+	if page < 1 {
+		page = 1
+	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(BrowsePageSize))
+
+	tempService := g.withConfig(strings.TrimSuffix(baseURL, "/"), &models.Config{Token: token})
+
+	resp, err := tempService.doRequest("GET", path+"?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("%s - %s", resp.Status, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	// GitLab leaves X-Next-Page empty on the last page
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return nextPage, nil
+}
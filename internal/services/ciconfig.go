@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CIConfigPath is where GitLab reads the pipeline configuration of a project by default
+const CIConfigPath = ".gitlab-ci.yml"
+
+// GetFile fetches a file of the repository at ref, nil if it doesn't exist
+func (g *GitLabService) GetFile(ref, path string) ([]byte, error) {
+	// The file path is a single segment of the URL, slashes included
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/files/%s/raw?ref=%s", g.projectID, url.PathEscape(path), url.QueryEscape(ref)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get %s: %s - %s", path, resp.Status, string(body))
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return content, nil
+}
+
+// GetRunFields fetches the CI configuration at ref and returns the inputs and variables
+// to ask for when running a pipeline, none if the project has no configuration
+func (g *GitLabService) GetRunFields(ref string) ([]models.RunField, error) {
+	content, err := g.GetFile(ref, CIConfigPath)
+	if err != nil || content == nil {
+		return nil, err
+	}
+	return ParseRunFields(content)
+}
+
+// ciInput is an entry of spec:inputs
+type ciInput struct {
+	Default     interface{}   `yaml:"default"`
+	Description string        `yaml:"description"`
+	Options     []interface{} `yaml:"options"`
+	Type        string        `yaml:"type"`
+}
+
+// ciVariable is a global variable declared with its description, as shown on the "Run pipeline" page
+type ciVariable struct {
+	Value       string   `yaml:"value"`
+	Description string   `yaml:"description"`
+	Options     []string `yaml:"options"`
+}
+
+// ParseRunFields returns the spec:inputs of a CI configuration and its global variables that
+// have a description, in the order they are declared. Included files are not followed.
+func ParseRunFields(content []byte) ([]models.RunField, error) {
+	var fields []models.RunField
+
+	// The header with spec:inputs is a document of its own, before the jobs
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse %s: %v", CIConfigPath, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+
+		inputs := mappingValue(mappingValue(root, "spec"), "inputs")
+		for i := 0; inputs != nil && i+1 < len(inputs.Content); i += 2 {
+			var input ciInput
+			if err := inputs.Content[i+1].Decode(&input); err != nil {
+				return nil, fmt.Errorf("failed to parse input %s: %v", inputs.Content[i].Value, err)
+			}
+			field := models.RunField{
+				Name:        inputs.Content[i].Value,
+				Input:       true,
+				Type:        input.Type,
+				Description: input.Description,
+				Value:       formatInputValue(input.Default),
+				Required:    input.Default == nil,
+			}
+			if field.Type == "" {
+				field.Type = "string"
+			}
+			for _, option := range input.Options {
+				field.Options = append(field.Options, formatInputValue(option))
+			}
+			if field.Type == "boolean" && len(field.Options) == 0 {
+				field.Options = []string{"true", "false"}
+			}
+			fields = append(fields, field)
+		}
+
+		variables := mappingValue(root, "variables")
+		for i := 0; variables != nil && i+1 < len(variables.Content); i += 2 {
+			// Plain KEY: value variables aren't offered by GitLab either
+			if variables.Content[i+1].Kind != yaml.MappingNode {
+				continue
+			}
+			var variable ciVariable
+			if err := variables.Content[i+1].Decode(&variable); err != nil {
+				return nil, fmt.Errorf("failed to parse variable %s: %v", variables.Content[i].Value, err)
+			}
+			if variable.Description == "" {
+				continue
+			}
+			value := variable.Value
+			if value == "" && len(variable.Options) > 0 {
+				value = variable.Options[0]
+			}
+			fields = append(fields, models.RunField{
+				Name:        variables.Content[i].Value,
+				Description: variable.Description,
+				Value:       value,
+				Options:     variable.Options,
+			})
+		}
+	}
+
+	return fields, nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, nil if node isn't a mapping or lacks key
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// formatInputValue renders an input value as typed in the run form, arrays as JSON
+func formatInputValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ParseInputValue converts the value typed for an input to the type it is declared with
+func ParseInputValue(field models.RunField, value string) (interface{}, error) {
+	switch field.Type {
+	case "number":
+		var number json.Number
+		if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &number); err != nil {
+			return nil, fmt.Errorf("input %s must be a number", field.Name)
+		}
+		return number, nil
+	case "boolean":
+		switch strings.TrimSpace(value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("input %s must be true or false", field.Name)
+	case "array":
+		var array []interface{}
+		if err := json.Unmarshal([]byte(value), &array); err != nil {
+			return nil, fmt.Errorf("input %s must be a JSON array, e.g. [\"a\", \"b\"]", field.Name)
+		}
+		return array, nil
+	default:
+		return value, nil
+	}
+}
@@ -1,28 +1,45 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"glcron/internal/models"
 	"os"
 	"path/filepath"
 )
 
+// ConfigBackupCount is how many previous versions of the config file are kept (glcron.json.bak.1 is the newest)
+const ConfigBackupCount = 3
+
+// ErrConfigChanged is returned by Save when another glcron wrote the config file since it was
+// read. The file is reloaded instead of overwritten, and the change has to be made again.
+var ErrConfigChanged = errors.New("the config file was changed by another glcron and was reloaded, make the change again")
+
 // ConfigServiceInterface defines the interface for config storage
 type ConfigServiceInterface interface {
 	Load() (*models.ConfigFile, error)
 	Save(configFile *models.ConfigFile) error
 	GetConfigPath() string
 	AddConfig(config models.Config) error
+	AddConfigs(configs []models.Config) error
 	UpdateConfig(index int, config models.Config) error
 	DeleteConfig(index int) error
 	GetConfigs() []models.Config
+	InstanceForURL(projectURL string) (models.Instance, bool)
+	SaveCurrent() error
+	SaveRefreshedCredentials() (bool, error)
+	GetCollapsedGroups() []string
+	SetCollapsedGroups(groups []string) error
+	SetWatchPipelines(watch bool) error
 }
 
 // ConfigService handles configuration file operations
 type ConfigService struct {
 	configPath string
 	configFile *models.ConfigFile
+	diskData   []byte // File contents last read or written, to detect writes of other instances
 }
 
 // NewConfigService creates a new ConfigService
@@ -37,6 +54,7 @@ func NewConfigService() ConfigServiceInterface {
 	return &ConfigService{
 		configPath: filepath.Join(configDir, "glcron.json"),
 		configFile: &models.ConfigFile{
+			Version: CurrentConfigVersion,
 			Configs: []models.Config{},
 		},
 	}
@@ -72,46 +90,195 @@ func (c *ConfigService) GetConfigPath() string {
 	return c.configPath
 }
 
-// Load loads the configuration file
+// Load loads the configuration file, migrating it to the current schema version if needed
 func (c *ConfigService) Load() (*models.ConfigFile, error) {
+	unlock, err := lockFile(c.configPath, false)
+	if err != nil {
+		return nil, err
+	}
 	data, err := os.ReadFile(c.configPath)
+	unlock()
+
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Return empty config if file doesn't exist
 			c.configFile = &models.ConfigFile{
+				Version: CurrentConfigVersion,
 				Configs: []models.Config{},
 			}
+			c.diskData = nil
 			return c.configFile, nil
 		}
 		return nil, fmt.Errorf("failed to read config file: %v", err)
 	}
 
-	if err := json.Unmarshal(data, c.configFile); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	configFile, migrated, err := parseConfigFile(data)
+	if err != nil {
+		return nil, err
+	}
+	c.configFile = configFile
+	c.diskData = data
+
+	if migrated {
+		if err := c.Save(c.configFile); err != nil {
+			return nil, fmt.Errorf("failed to save migrated config: %v", err)
+		}
 	}
 
 	return c.configFile, nil
 }
 
-// Save saves the configuration file
+// parseConfigFile decodes the config file and migrates it to the current schema version
+func parseConfigFile(data []byte) (*models.ConfigFile, bool, error) {
+	// Decode into a fresh struct so a missing version field reads as version 0
+	configFile := &models.ConfigFile{}
+	if err := json.Unmarshal(data, configFile); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file: %v", err)
+	}
+
+	migrated, err := migrateConfigFile(configFile)
+	if err != nil {
+		return nil, false, err
+	}
+	return configFile, migrated, nil
+}
+
+// Save saves the configuration file atomically, keeping a backup of the previous version.
+// If another glcron wrote the file since it was read, the file is reloaded instead and
+// ErrConfigChanged is returned.
 func (c *ConfigService) Save(configFile *models.ConfigFile) error {
 	c.configFile = configFile
+	configFile.Version = CurrentConfigVersion
 
+	// OAuth credentials are shared with the services and may be refreshed by a request meanwhile
+	oauthRefreshMu.Lock()
 	data, err := json.MarshalIndent(configFile, "", "  ")
+	oauthRefreshMu.Unlock()
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %v", err)
 	}
 
-	if err := os.WriteFile(c.configPath, data, 0600); err != nil {
+	unlock, err := lockFile(c.configPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := os.ReadFile(c.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	// Another glcron saved in the meantime, writing would lose its changes
+	if current != nil && !bytes.Equal(current, c.diskData) {
+		reloaded, _, err := parseConfigFile(current)
+		if err != nil {
+			return err
+		}
+		c.configFile = reloaded
+		c.diskData = current
+		return ErrConfigChanged
+	}
+
+	// Nothing changed, keep the existing backups
+	if bytes.Equal(current, data) {
+		return nil
+	}
+
+	// Opening a project only records when, that is not worth a backup
+	if current != nil && !onlyOpenTimesChanged(current, data) {
+		if err := c.rotateBackups(current); err != nil {
+			return fmt.Errorf("failed to back up config file: %v", err)
+		}
+	}
+
+	if err := writeFileAtomic(c.configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %v", err)
 	}
+	c.diskData = data
 
 	return nil
 }
 
+// onlyOpenTimesChanged returns true if two versions of the config file only differ in the
+// last_opened_at of their configs
+func onlyOpenTimesChanged(a, b []byte) bool {
+	var fileA, fileB models.ConfigFile
+	if json.Unmarshal(a, &fileA) != nil || json.Unmarshal(b, &fileB) != nil {
+		return false
+	}
+	for _, file := range []*models.ConfigFile{&fileA, &fileB} {
+		for i := range file.Configs {
+			file.Configs[i].LastOpenedAt = nil
+		}
+	}
+
+	dataA, errA := json.Marshal(fileA)
+	dataB, errB := json.Marshal(fileB)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// rotateBackups shifts glcron.json.bak.N files and writes the current contents to glcron.json.bak.1
+func (c *ConfigService) rotateBackups(current []byte) error {
+	for i := ConfigBackupCount - 1; i >= 1; i-- {
+		older := fmt.Sprintf("%s.bak.%d", c.configPath, i)
+		if err := os.Rename(older, fmt.Sprintf("%s.bak.%d", c.configPath, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return writeFileAtomic(c.configPath+".bak.1", current, 0600)
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and renames it over path,
+// so readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// Clean up the temp file unless it was renamed into place
+	success := false
+	defer func() {
+		if !success {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	success = true
+	return nil
+}
+
 // AddConfig adds a new configuration
 func (c *ConfigService) AddConfig(config models.Config) error {
-	c.configFile.Configs = append(c.configFile.Configs, config)
+	c.configFile.Configs = append(c.configFile.Configs, c.storeCredentials(config))
+	return c.Save(c.configFile)
+}
+
+// AddConfigs adds several configurations with a single write
+func (c *ConfigService) AddConfigs(configs []models.Config) error {
+	for _, config := range configs {
+		c.configFile.Configs = append(c.configFile.Configs, c.storeCredentials(config))
+	}
 	return c.Save(c.configFile)
 }
 
@@ -121,7 +288,8 @@ func (c *ConfigService) UpdateConfig(index int, config models.Config) error {
 		return fmt.Errorf("invalid config index: %d", index)
 	}
 
-	c.configFile.Configs[index] = config
+	c.configFile.Configs[index] = c.storeCredentials(config)
+	c.pruneInstances()
 	return c.Save(c.configFile)
 }
 
@@ -132,10 +300,64 @@ func (c *ConfigService) DeleteConfig(index int) error {
 	}
 
 	c.configFile.Configs = append(c.configFile.Configs[:index], c.configFile.Configs[index+1:]...)
+	c.pruneInstances()
 	return c.Save(c.configFile)
 }
 
-// GetConfigs returns all configurations
+// GetConfigs returns all configurations with the credentials of their instance filled in
 func (c *ConfigService) GetConfigs() []models.Config {
-	return c.configFile.Configs
+	configs := make([]models.Config, len(c.configFile.Configs))
+	for i, config := range c.configFile.Configs {
+		configs[i] = c.resolveConfig(config)
+	}
+	return configs
+}
+
+// SaveCurrent saves the in-memory configuration, e.g. after OAuth credentials were refreshed
+func (c *ConfigService) SaveCurrent() error {
+	return c.Save(c.configFile)
+}
+
+// SaveRefreshedCredentials saves the OAuth credentials refreshed by requests. If another glcron
+// saved the file meanwhile, the file is reloaded and the refreshed credentials are carried over
+// to it, GitLab already invalidated the refresh tokens the file holds. Returns true if the
+// file was reloaded.
+func (c *ConfigService) SaveRefreshedCredentials() (bool, error) {
+	refreshed := make(map[string]*models.OAuthCredentials)
+	for _, instance := range c.configFile.Instances {
+		if instance.OAuth != nil {
+			refreshed[instance.Name] = instance.OAuth
+		}
+	}
+
+	err := c.SaveCurrent()
+	if !errors.Is(err, ErrConfigChanged) {
+		return false, err
+	}
+
+	for i := range c.configFile.Instances {
+		instance := &c.configFile.Instances[i]
+		// Shared with the services again, so later refreshes are saved too
+		if creds, ok := refreshed[instance.Name]; ok && instance.OAuth != nil && instance.OAuth.ApplicationID == creds.ApplicationID {
+			instance.OAuth = creds
+		}
+	}
+	return true, c.Save(c.configFile)
+}
+
+// GetCollapsedGroups returns the config list groups that are folded
+func (c *ConfigService) GetCollapsedGroups() []string {
+	return c.configFile.CollapsedGroups
+}
+
+// SetCollapsedGroups stores which config list groups are folded
+func (c *ConfigService) SetCollapsedGroups(groups []string) error {
+	c.configFile.CollapsedGroups = groups
+	return c.Save(c.configFile)
+}
+
+// SetWatchPipelines stores whether pipelines started from glcron are watched until they finish
+func (c *ConfigService) SetWatchPipelines(watch bool) error {
+	c.configFile.WatchPipelines = watch
+	return c.Save(c.configFile)
 }
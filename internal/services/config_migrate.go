@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"glcron/internal/models"
+)
+
+// CurrentConfigVersion is the schema version written to glcron.json
+const CurrentConfigVersion = 2
+
+// configMigrations upgrade a config file by one version, configMigrations[i] upgrades version i to i+1
+var configMigrations = []func(configFile *models.ConfigFile) error{
+	migrateV0ToV1,
+	migrateV1ToV2,
+}
+
+// migrateConfigFile upgrades a config file to CurrentConfigVersion, returns true if anything changed
+func migrateConfigFile(configFile *models.ConfigFile) (bool, error) {
+	if configFile.Version > CurrentConfigVersion {
+		return false, fmt.Errorf("config file version %d is newer than supported version %d, please upgrade glcron", configFile.Version, CurrentConfigVersion)
+	}
+
+	migrated := false
+	for configFile.Version < CurrentConfigVersion {
+		if err := configMigrations[configFile.Version](configFile); err != nil {
+			return false, fmt.Errorf("failed to migrate config from version %d: %v", configFile.Version, err)
+		}
+		configFile.Version++
+		migrated = true
+	}
+
+	return migrated, nil
+}
+
+// migrateV0ToV1 makes the auth method explicit on configs written before OAuth support
+func migrateV0ToV1(configFile *models.ConfigFile) error {
+	for i := range configFile.Configs {
+		if configFile.Configs[i].AuthType == "" {
+			configFile.Configs[i].AuthType = models.AuthTypeToken
+		}
+	}
+	return nil
+}
+
+// migrateV1ToV2 moves the credentials duplicated on every config into instance profiles,
+// one per host and distinct set of credentials
+func migrateV1ToV2(configFile *models.ConfigFile) error {
+	for i := range configFile.Configs {
+		config := &configFile.Configs[i]
+		if config.Instance != "" {
+			continue
+		}
+
+		baseURL, err := BaseURLFromProjectURL(config.ProjectURL)
+		if err != nil {
+			// Keep the credentials on the config, it can't be matched to a host
+			continue
+		}
+
+		idx := -1
+		for j, instance := range configFile.Instances {
+			if instance.BaseURL == baseURL && sameCredentials(instance, *config) {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			configFile.Instances = append(configFile.Instances, models.Instance{
+				Name:     uniqueInstanceName(configFile.Instances, baseURL),
+				BaseURL:  baseURL,
+				Token:    config.Token,
+				AuthType: config.AuthType,
+				OAuth:    config.OAuth,
+			})
+			idx = len(configFile.Instances) - 1
+		}
+
+		config.Instance = configFile.Instances[idx].Name
+		stripCredentials(config)
+	}
+	return nil
+}
+
+// sameCredentials returns true if the config authenticates exactly like the instance
+func sameCredentials(instance models.Instance, config models.Config) bool {
+	if instance.Token != config.Token || instance.AuthType != config.AuthType {
+		return false
+	}
+	if instance.OAuth == nil || config.OAuth == nil {
+		return instance.OAuth == nil && config.OAuth == nil
+	}
+	instanceOAuth, configOAuth := SnapshotOAuth(instance.OAuth), SnapshotOAuth(config.OAuth)
+	return instanceOAuth.ApplicationID == configOAuth.ApplicationID &&
+		instanceOAuth.RefreshToken == configOAuth.RefreshToken
+}
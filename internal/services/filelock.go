@@ -0,0 +1,41 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileLockTimeout is how long to wait for another glcron instance to release the config lock
+const fileLockTimeout = 5 * time.Second
+
+// lockFile takes an advisory lock on path+".lock" and returns a function releasing it.
+// Exclusive locks are used for writes, shared locks for reads.
+func lockFile(path string, exclusive bool) (func(), error) {
+	lockPath := path + ".lock"
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %v", err)
+	}
+
+	deadline := time.Now().Add(fileLockTimeout)
+	for {
+		locked, err := tryLockFile(f, exclusive)
+		if locked {
+			break
+		}
+		if err != nil || time.Now().After(deadline) {
+			f.Close()
+			if err == nil {
+				err = fmt.Errorf("timed out after %s", fileLockTimeout)
+			}
+			return nil, fmt.Errorf("failed to lock %s, is another glcron writing to it? %v", path, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"glcron/internal/models"
@@ -8,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,40 +19,89 @@ import (
 type GitLabServiceInterface interface {
 	SetConfig(config *models.Config) error
 	GetSchedules() ([]models.Schedule, error)
+	GetSchedulesFor(config *models.Config) ([]models.Schedule, error)
 	GetSchedule(id int) (*models.Schedule, error)
 	CreateSchedule(req *models.ScheduleCreateRequest) (*models.Schedule, error)
 	UpdateSchedule(id int, req *models.ScheduleUpdateRequest) (*models.Schedule, error)
 	DeleteSchedule(id int) error
 	RunSchedule(id int) error
+	WaitForSchedulePipelineFor(config *models.Config, id, previousID int) (*models.Pipeline, error)
 	TakeOwnership(id int) (*models.Schedule, error)
+	GetSchedulePipelines(id int, limit int) ([]models.Pipeline, error)
+	GetScheduleReliability(scheduleID, runs int) (*models.ScheduleReliability, error)
+	FillPipelineDurations(pipelines []models.Pipeline) error
 	GetCurrentUser() (*models.User, error)
-	GetBranches() ([]models.Branch, error)
+	ListBranches(search string, page int) ([]models.Branch, int, error)
+	ListTags(search string, page int) ([]models.Tag, int, error)
+	ResolveRef(ref string) (*models.ResolvedRef, error)
+	CheckScheduleRefs(schedules []models.Schedule) ([]models.RefCheck, error)
 	CreateVariable(scheduleID int, variable *models.Variable) error
 	UpdateVariable(scheduleID int, variable *models.Variable) error
 	DeleteVariable(scheduleID int, key string) error
 	ValidateConfig(config *models.Config) error
+	CheckTokenHealth(config *models.Config, expiryWarningDays int) (*models.TokenHealth, error)
+	// OAuth device login
+	StartDeviceLogin(baseURL, applicationID string) (*models.DeviceAuthorization, error)
+	PollDeviceLogin(baseURL, applicationID, deviceCode string) (*models.OAuthCredentials, error)
+	OnTokenRefresh(handler func(creds *models.OAuthCredentials))
+	// Browsing groups and projects of an instance
+	ListGroups(baseURL, token, search string, page int) ([]models.Group, int, error)
+	ListProjects(baseURL, token string, groupID int, search string, page int) ([]models.Project, int, error)
 	// Pipeline operations for Quick Run
 	CreatePipeline(req *models.PipelineCreateRequest) (*models.Pipeline, error)
+	GetRunFields(ref string) ([]models.RunField, error)
 	GetPipelines(limit int) ([]models.Pipeline, error)
+	ListPipelines(filter models.PipelineFilter, page, perPage int) ([]models.Pipeline, int, error)
 	GetPipeline(pipelineID int) (*models.Pipeline, error)
 	GetPipelineJobs(pipelineID int) ([]models.PipelineJob, error)
 	GetPipelineBridges(pipelineID int) ([]models.PipelineBridge, error)
+	GetPipelineVariables(pipelineID int) ([]models.Variable, error)
+	GetPipelineFor(config *models.Config, pipelineID int) (*models.Pipeline, []models.PipelineJob, error)
+	CancelPipeline(pipelineID int) error
+	RetryPipeline(pipelineID int) error
+	// Jobs
+	GetJob(jobID int) (*models.PipelineJob, error)
+	GetJobTrace(jobID int, offset int64) ([]byte, bool, error)
+	CancelJob(jobID int) error
+	RetryJob(jobID int) error
+	PlayJob(jobID int) error
+	// Job artifacts
+	GetArtifactsArchive(jobID int) (*ArtifactArchive, error)
+	DownloadArtifacts(jobID int, w io.Writer) error
+	DownloadArtifactFile(jobID int, path string, w io.Writer) error
 }
 
+// DefaultTokenExpiryWarningDays is how close to its expiry date a token starts being flagged,
+// unless the config file sets token_expiry_warning_days
+const DefaultTokenExpiryWarningDays = 14
+
 // GitLabService handles GitLab API interactions
 type GitLabService struct {
 	baseURL   string
 	projectID int
 	token     string
 	client    *http.Client
+	// Without a total timeout, for artifacts that take long to download
+	downloadClient *http.Client
+
+	// OAuth credentials, refreshed transparently by doRequest when set
+	oauth          *models.OAuthCredentials
+	onTokenRefresh func(creds *models.OAuthCredentials)
 }
 
 // NewGitLabService creates a new GitLabService
 func NewGitLabService() GitLabServiceInterface {
+	// Only waiting for the response headers is limited, the body may take as long as it needs
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = 30 * time.Second
+
 	return &GitLabService{
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		downloadClient: &http.Client{
+			Transport: transport,
+		},
 	}
 }
 
@@ -67,6 +119,10 @@ func (g *GitLabService) SetConfig(config *models.Config) error {
 
 	g.baseURL = baseURL
 	g.token = config.Token
+	g.oauth = nil
+	if config.UsesOAuth() {
+		g.oauth = config.OAuth
+	}
 
 	// Get project ID from API
 	projectID, err := g.getProjectID(projectPath)
@@ -95,7 +151,11 @@ func (g *GitLabService) ValidateConfig(config *models.Config) error {
 		return fmt.Errorf("project URL is required")
 	}
 
-	if config.Token == "" {
+	if config.UsesOAuth() {
+		if creds := SnapshotOAuth(config.OAuth); creds == nil || creds.AccessToken == "" {
+			return fmt.Errorf("OAuth login is required")
+		}
+	} else if config.Token == "" {
 		return fmt.Errorf("token is required")
 	}
 
@@ -106,12 +166,8 @@ func (g *GitLabService) ValidateConfig(config *models.Config) error {
 	}
 
 	// Try to get project ID to validate credentials
-	tempService := &GitLabService{
-		client: g.client,
-		token:  config.Token,
-	}
 	baseURL, projectPath, _ := parseProjectURL(config.ProjectURL)
-	tempService.baseURL = baseURL
+	tempService := g.withConfig(baseURL, config)
 
 	_, err = tempService.getProjectID(projectPath)
 	if err != nil {
@@ -121,6 +177,189 @@ func (g *GitLabService) ValidateConfig(config *models.Config) error {
 	return nil
 }
 
+// CheckTokenHealth inspects the token's scopes and expiry and the user's role in the project.
+// Tokens expiring within expiryWarningDays are flagged, 0 uses the default.
+func (g *GitLabService) CheckTokenHealth(config *models.Config, expiryWarningDays int) (*models.TokenHealth, error) {
+	if expiryWarningDays <= 0 {
+		expiryWarningDays = DefaultTokenExpiryWarningDays
+	}
+
+	tempService, err := g.forConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := tempService.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	health := &models.TokenHealth{Username: user.Username}
+
+	// Token details are not available on older instances
+	var tokenInfo *models.TokenInfo
+	if config.UsesOAuth() {
+		tokenInfo, err = tempService.getOAuthTokenInfo()
+	} else {
+		tokenInfo, err = tempService.getTokenInfo()
+	}
+	if err != nil {
+		health.Warnings = append(health.Warnings, "Token scopes and expiry unavailable")
+	} else {
+		health.TokenName = tokenInfo.Name
+		health.Scopes = tokenInfo.Scopes
+		if tokenInfo.ExpiresAt != "" {
+			if expiresAt, err := time.Parse("2006-01-02", tokenInfo.ExpiresAt); err == nil {
+				health.ExpiresAt = &expiresAt
+			}
+		}
+	}
+
+	accessLevel, err := tempService.getAccessLevel(tempService.projectID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	health.AccessLevel = accessLevel
+
+	if health.ExpiresAt != nil {
+		daysLeft := int(time.Until(*health.ExpiresAt).Hours() / 24)
+		if daysLeft < 0 {
+			health.Warnings = append(health.Warnings, "Token has expired")
+		} else if daysLeft <= expiryWarningDays {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("Token expires in %d days", daysLeft))
+		}
+	}
+	if health.Scopes != nil && !hasScope(health.Scopes, "api") {
+		health.Warnings = append(health.Warnings, "Token lacks the api scope")
+	}
+	if health.AccessLevel < models.AccessLevelMaintainer {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("Maintainer role needed to edit schedules (you are %s)", models.AccessLevelName(health.AccessLevel)))
+	}
+
+	return health, nil
+}
+
+// getTokenInfo fetches details of the token used for authentication
+func (g *GitLabService) getTokenInfo() (*models.TokenInfo, error) {
+	resp, err := g.doRequest("GET", "/api/v4/personal_access_tokens/self", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get token info: %s - %s", resp.Status, string(body))
+	}
+
+	var info models.TokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode token info: %v", err)
+	}
+
+	return &info, nil
+}
+
+// getOAuthTokenInfo fetches the scopes of the OAuth access token.
+// Expiry is left empty since access tokens are refreshed automatically.
+func (g *GitLabService) getOAuthTokenInfo() (*models.TokenInfo, error) {
+	resp, err := g.doRequest("GET", "/oauth/token/info", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get token info: %s - %s", resp.Status, string(body))
+	}
+
+	var info struct {
+		Scope []string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode token info: %v", err)
+	}
+
+	return &models.TokenInfo{Name: "OAuth", Scopes: info.Scope, Active: true}, nil
+}
+
+// getAccessLevel returns the user's effective access level in a project, including inherited membership
+func (g *GitLabService) getAccessLevel(projectID, userID int) (int, error) {
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/members/all/%d", projectID, userID), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Not a member (e.g. access through an admin account or a public project)
+	if resp.StatusCode == http.StatusNotFound {
+		return models.AccessLevelNone, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("failed to get project membership: %s - %s", resp.Status, string(body))
+	}
+
+	var member struct {
+		AccessLevel int `json:"access_level"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return 0, fmt.Errorf("failed to decode project membership: %v", err)
+	}
+
+	return member.AccessLevel, nil
+}
+
+// hasScope checks whether a token scope list contains the given scope
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// withConfig returns a service that talks to the given instance with the config's credentials
+func (g *GitLabService) withConfig(baseURL string, config *models.Config) *GitLabService {
+	tempService := &GitLabService{
+		client:         g.client,
+		downloadClient: g.downloadClient,
+		baseURL:        baseURL,
+		token:          config.Token,
+		onTokenRefresh: g.onTokenRefresh,
+	}
+	if config.UsesOAuth() {
+		tempService.oauth = config.OAuth
+	}
+	return tempService
+}
+
+// forConfig returns a service bound to config's project, looking up the project ID
+// unless the config already knows it for the project's instance
+func (g *GitLabService) forConfig(config *models.Config) (*GitLabService, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+
+	baseURL, projectPath, err := parseProjectURL(config.ProjectURL)
+	if err != nil {
+		return nil, err
+	}
+	tempService := g.withConfig(baseURL, config)
+
+	tempService.projectID = config.ProjectID
+	if tempService.projectID == 0 || config.BaseURL != baseURL {
+		tempService.projectID, err = tempService.getProjectID(projectPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tempService, nil
+}
+
 // parseProjectURL extracts base URL and project path from GitLab URL
 func parseProjectURL(projectURL string) (baseURL, projectPath string, err error) {
 	// Remove trailing slash
@@ -149,6 +388,12 @@ func parseProjectURL(projectURL string) (baseURL, projectPath string, err error)
 	return baseURL, projectPath, nil
 }
 
+// BaseURLFromProjectURL returns the GitLab instance URL of a project URL
+func BaseURLFromProjectURL(projectURL string) (string, error) {
+	baseURL, _, err := parseProjectURL(projectURL)
+	return baseURL, err
+}
+
 // getProjectID gets the project ID from the project path
 func (g *GitLabService) getProjectID(projectPath string) (int, error) {
 	// URL encode the project path
@@ -205,6 +450,34 @@ func (g *GitLabService) GetSchedules() ([]models.Schedule, error) {
 	return schedules, nil
 }
 
+// GetSchedulesFor fetches the schedules of any config's project, leaving the selected config untouched
+func (g *GitLabService) GetSchedulesFor(config *models.Config) ([]models.Schedule, error) {
+	tempService, err := g.forConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return tempService.GetSchedules()
+}
+
+// GetPipelineFor fetches a pipeline and its jobs from the project of config, without switching to it
+func (g *GitLabService) GetPipelineFor(config *models.Config, pipelineID int) (*models.Pipeline, []models.PipelineJob, error) {
+	tempService, err := g.forConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := tempService.GetPipeline(pipelineID)
+	if err != nil {
+		return nil, nil, err
+	}
+	jobs, err := tempService.GetPipelineJobs(pipelineID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pipeline, jobs, nil
+}
+
 // GetSchedule fetches a single schedule with full details
 func (g *GitLabService) GetSchedule(id int) (*models.Schedule, error) {
 	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipeline_schedules/%d", g.projectID, id), nil)
@@ -320,7 +593,14 @@ func (g *GitLabService) DeleteSchedule(id int) error {
 	return nil
 }
 
-// RunSchedule triggers a pipeline schedule to run immediately
+// How long WaitForSchedulePipelineFor waits for GitLab to create the pipeline
+const (
+	runSchedulePollInterval = time.Second
+	runScheduleAttempts     = 10
+)
+
+// RunSchedule triggers a pipeline schedule to run immediately. GitLab creates the pipeline
+// in the background, see WaitForSchedulePipelineFor.
 func (g *GitLabService) RunSchedule(id int) error {
 	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/pipeline_schedules/%d/play", g.projectID, id), nil)
 	if err != nil {
@@ -332,10 +612,103 @@ func (g *GitLabService) RunSchedule(id int) error {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("failed to run schedule: %s - %s", resp.Status, string(body))
 	}
-
 	return nil
 }
 
+// WaitForSchedulePipelineFor polls a schedule of config's project until its last pipeline
+// is another one than previousID, and returns it. nil is returned if it didn't show up in time.
+func (g *GitLabService) WaitForSchedulePipelineFor(config *models.Config, id, previousID int) (*models.Pipeline, error) {
+	tempService, err := g.forConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < runScheduleAttempts; i++ {
+		schedule, err := tempService.GetSchedule(id)
+		if err == nil && schedule.LastPipeline != nil && schedule.LastPipeline.ID != previousID {
+			return schedule.LastPipeline, nil
+		}
+		time.Sleep(runSchedulePollInterval)
+	}
+
+	return nil, nil
+}
+
+// GetSchedulePipelines fetches the latest pipelines triggered by a pipeline schedule, newest first
+func (g *GitLabService) GetSchedulePipelines(id int, limit int) ([]models.Pipeline, error) {
+	pipelines, totalPages, nextPage, err := g.getSchedulePipelinesPage(id, limit, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Older GitLab versions ignore sort and return the oldest first, the latest pipelines
+	// are then on the last pages
+	ascending := len(pipelines) > 1 && pipelines[0].ID < pipelines[len(pipelines)-1].ID
+	if ascending && nextPage > 0 {
+		if totalPages > 0 {
+			// The last page may be partial, the one before completes it
+			pipelines = nil
+			for page := totalPages - 1; page <= totalPages; page++ {
+				if page < 1 {
+					continue
+				}
+				more, _, _, err := g.getSchedulePipelinesPage(id, limit, page)
+				if err != nil {
+					return nil, err
+				}
+				pipelines = append(pipelines, more...)
+			}
+		} else {
+			// GitLab leaves out X-Total-Pages for large collections, go through every page
+			for nextPage > 0 {
+				var more []models.Pipeline
+				more, _, nextPage, err = g.getSchedulePipelinesPage(id, limit, nextPage)
+				if err != nil {
+					return nil, err
+				}
+				pipelines = append(pipelines, more...)
+				if len(pipelines) > limit {
+					pipelines = pipelines[len(pipelines)-limit:]
+				}
+			}
+		}
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].ID > pipelines[j].ID
+	})
+	if len(pipelines) > limit {
+		pipelines = pipelines[:limit]
+	}
+
+	return pipelines, nil
+}
+
+// getSchedulePipelinesPage fetches a page of the pipelines of a schedule, with the number of
+// pages (0 when GitLab doesn't tell) and the next page (0 on the last one)
+func (g *GitLabService) getSchedulePipelinesPage(id, perPage, page int) ([]models.Pipeline, int, int, error) {
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipeline_schedules/%d/pipelines?per_page=%d&page=%d&sort=desc", g.projectID, id, perPage, page), nil)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, 0, fmt.Errorf("failed to get schedule pipelines: %s - %s", resp.Status, string(body))
+	}
+
+	var pipelines []models.Pipeline
+	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode schedule pipelines: %v", err)
+	}
+
+	totalPages, _ := strconv.Atoi(resp.Header.Get("X-Total-Pages"))
+	// GitLab leaves X-Next-Page empty on the last page
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return pipelines, totalPages, nextPage, nil
+}
+
 // TakeOwnership takes ownership of a pipeline schedule
 func (g *GitLabService) TakeOwnership(id int) (*models.Schedule, error) {
 	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/pipeline_schedules/%d/take_ownership", g.projectID, id), nil)
@@ -378,48 +751,6 @@ func (g *GitLabService) GetCurrentUser() (*models.User, error) {
 	return &user, nil
 }
 
-// GetBranches fetches all branches
-func (g *GitLabService) GetBranches() ([]models.Branch, error) {
-	var allBranches []models.Branch
-	page := 1
-	perPage := 100
-
-	for {
-		resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/branches?page=%d&per_page=%d", g.projectID, page, perPage), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			return nil, fmt.Errorf("failed to get branches: %s - %s", resp.Status, string(body))
-		}
-
-		var branches []models.Branch
-		if err := json.NewDecoder(resp.Body).Decode(&branches); err != nil {
-			resp.Body.Close()
-			return nil, fmt.Errorf("failed to decode branches: %v", err)
-		}
-		resp.Body.Close()
-
-		allBranches = append(allBranches, branches...)
-
-		// Check if we got all branches
-		if len(branches) < perPage {
-			break
-		}
-		page++
-
-		// Safety limit
-		if page > 10 {
-			break
-		}
-	}
-
-	return allBranches, nil
-}
-
 // CreateVariable creates a new variable for a schedule
 func (g *GitLabService) CreateVariable(scheduleID int, variable *models.Variable) error {
 	data := url.Values{}
@@ -483,21 +814,22 @@ func (g *GitLabService) DeleteVariable(scheduleID int, key string) error {
 
 // CreatePipeline creates a new pipeline run
 func (g *GitLabService) CreatePipeline(req *models.PipelineCreateRequest) (*models.Pipeline, error) {
-	data := url.Values{}
-	data.Set("ref", req.Ref)
-
-	// Add variables
-	for i, v := range req.Variables {
-		data.Set(fmt.Sprintf("variables[%d][key]", i), v.Key)
-		data.Set(fmt.Sprintf("variables[%d][value]", i), v.Value)
-		varType := v.VariableType
-		if varType == "" {
-			varType = "env_var"
+	// Sent as JSON so inputs keep their type
+	payload := models.PipelineCreateRequest{Ref: req.Ref, Inputs: req.Inputs}
+	for _, v := range req.Variables {
+		if v.VariableType == "" {
+			v.VariableType = "env_var"
 		}
-		data.Set(fmt.Sprintf("variables[%d][variable_type]", i), varType)
+		payload.Variables = append(payload.Variables, v)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pipeline: %v", err)
 	}
 
-	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/pipeline", g.projectID), strings.NewReader(data.Encode()))
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	resp, err := g.doRequestWithHeader("POST", fmt.Sprintf("/api/v4/projects/%d/pipeline", g.projectID), bytes.NewReader(data), header)
 	if err != nil {
 		return nil, err
 	}
@@ -537,6 +869,57 @@ func (g *GitLabService) GetPipelines(limit int) ([]models.Pipeline, error) {
 	return pipelines, nil
 }
 
+// ListPipelines fetches a page of the project's pipelines matching filter, newest first.
+// It returns the next page, 0 on the last one.
+func (g *GitLabService) ListPipelines(filter models.PipelineFilter, page, perPage int) ([]models.Pipeline, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+	params.Set("order_by", "id")
+	params.Set("sort", "desc")
+	if filter.Ref != "" {
+		params.Set("ref", filter.Ref)
+	}
+	if filter.Status != "" {
+		params.Set("status", filter.Status)
+	}
+	if filter.Source != "" {
+		params.Set("source", filter.Source)
+	}
+	if filter.Username != "" {
+		params.Set("username", filter.Username)
+	}
+	if filter.UpdatedAfter != nil {
+		params.Set("updated_after", filter.UpdatedAfter.UTC().Format(time.RFC3339))
+	}
+	if filter.UpdatedBefore != nil {
+		params.Set("updated_before", filter.UpdatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipelines?%s", g.projectID, params.Encode()), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("failed to get pipelines: %s - %s", resp.Status, string(body))
+	}
+
+	var pipelines []models.Pipeline
+	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode pipelines: %v", err)
+	}
+
+	// GitLab leaves X-Next-Page empty on the last page
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return pipelines, nextPage, nil
+}
+
 // GetPipelineJobs fetches jobs for a pipeline
 func (g *GitLabService) GetPipelineJobs(pipelineID int) ([]models.PipelineJob, error) {
 	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipelines/%d/jobs?per_page=100", g.projectID, pipelineID), nil)
@@ -600,21 +983,131 @@ func (g *GitLabService) GetPipelineBridges(pipelineID int) ([]models.PipelineBri
 	return bridges, nil
 }
 
+// GetPipelineVariables fetches the variables a pipeline was started with
+func (g *GitLabService) GetPipelineVariables(pipelineID int) ([]models.Variable, error) {
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipelines/%d/variables", g.projectID, pipelineID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get pipeline variables: %s - %s", resp.Status, string(body))
+	}
+
+	var variables []models.Variable
+	if err := json.NewDecoder(resp.Body).Decode(&variables); err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline variables: %v", err)
+	}
+
+	return variables, nil
+}
+
+// CancelPipeline cancels all jobs of a pipeline that have not finished
+func (g *GitLabService) CancelPipeline(pipelineID int) error {
+	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/pipelines/%d/cancel", g.projectID, pipelineID), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cancel pipeline: %s - %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// RetryPipeline retries the failed and canceled jobs of a pipeline
+func (g *GitLabService) RetryPipeline(pipelineID int) error {
+	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/pipelines/%d/retry", g.projectID, pipelineID), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to retry pipeline: %s - %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
 // doRequest performs an HTTP request
 func (g *GitLabService) doRequest(method, path string, body io.Reader) (*http.Response, error) {
+	return g.doRequestWithHeader(method, path, body, nil)
+}
+
+// doRequestWithHeader performs an HTTP request with extra headers, e.g. Range
+func (g *GitLabService) doRequestWithHeader(method, path string, body io.Reader, header http.Header) (*http.Response, error) {
+	return g.doRequestWith(g.client, method, path, body, header)
+}
+
+// doRequestWith performs an HTTP request with the given client
+func (g *GitLabService) doRequestWith(client *http.Client, method, path string, body io.Reader, header http.Header) (*http.Response, error) {
+	if g.oauth == nil {
+		return g.send(client, method, path, body, header)
+	}
+
+	// Buffer the body so the request can be replayed after a token refresh
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := g.refreshOAuthToken(false); err != nil {
+		return nil, err
+	}
+
+	resp, err := g.send(client, method, path, bytesReader(payload, body != nil), header)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token may have been revoked or expired early, refresh once and retry
+	resp.Body.Close()
+	if err := g.refreshOAuthToken(true); err != nil {
+		return nil, err
+	}
+	return g.send(client, method, path, bytesReader(payload, body != nil), header)
+}
+
+// send builds and sends an authenticated HTTP request
+func (g *GitLabService) send(client *http.Client, method, path string, body io.Reader, header http.Header) (*http.Response, error) {
 	reqURL := g.baseURL + path
 
 	req, err := http.NewRequest(method, reqURL, body)
 	if err != nil {
 		return nil, err
 	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
-	req.Header.Set("PRIVATE-TOKEN", g.token)
-	if body != nil {
+	if g.oauth != nil {
+		req.Header.Set("Authorization", "Bearer "+g.accessToken())
+	} else {
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	return g.client.Do(req)
+	return client.Do(req)
+}
+
+// bytesReader returns a reader over a buffered request body, or nil if there was no body
+func bytesReader(payload []byte, hasBody bool) io.Reader {
+	if !hasBody {
+		return nil
+	}
+	return bytes.NewReader(payload)
 }
 
 // Common timezones for the dropdown
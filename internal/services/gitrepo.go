@@ -0,0 +1,102 @@
+package services
+
+import (
+	"glcron/internal/models"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// GitRemoteURLs returns the remote URLs of the git checkout containing dir, "origin" first.
+// Returns nil outside a checkout or when git isn't installed.
+func GitRemoteURLs(dir string) []string {
+	out, err := exec.Command("git", "-C", dir, "config", "--get-regexp", `^remote\..*\.url$`).Output()
+	if err != nil {
+		return nil
+	}
+
+	type remote struct {
+		name string
+		url  string
+	}
+	var remotes []remote
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(fields[0], "remote."), ".url")
+		remotes = append(remotes, remote{name: name, url: fields[1]})
+	}
+
+	sort.SliceStable(remotes, func(i, j int) bool {
+		return remotes[i].name == "origin" && remotes[j].name != "origin"
+	})
+
+	urls := make([]string, len(remotes))
+	for i, r := range remotes {
+		urls[i] = r.url
+	}
+	return urls
+}
+
+// ProjectKey normalizes a project URL or git remote URL to "host/group/project" so that
+// https and SSH remotes of the same project compare equal. Returns "" if it can't be parsed.
+func ProjectKey(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+
+	var host, path string
+	if !strings.Contains(rawURL, "://") {
+		// SCP-like SSH syntax: git@gitlab.com:group/project.git
+		at := strings.Index(rawURL, "@")
+		colon := strings.Index(rawURL, ":")
+		if colon < 0 || colon < at {
+			return ""
+		}
+		host = rawURL[at+1 : colon]
+		path = rawURL[colon+1:]
+	} else {
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return ""
+		}
+		host = parsed.Hostname() // Drops the SSH port
+		path = parsed.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return ""
+	}
+	return strings.ToLower(host + "/" + path)
+}
+
+// FindConfigByName returns the index of the config with the given name, preferring an exact match
+func FindConfigByName(configs []models.Config, name string) int {
+	for i, config := range configs {
+		if config.Name == name {
+			return i
+		}
+	}
+	for i, config := range configs {
+		if strings.EqualFold(config.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindConfigByProject returns the index of the config of a project URL or git remote URL
+func FindConfigByProject(configs []models.Config, projectURL string) int {
+	key := ProjectKey(projectURL)
+	if key == "" {
+		return -1
+	}
+	for i, config := range configs {
+		if ProjectKey(config.ProjectURL) == key {
+			return i
+		}
+	}
+	return -1
+}
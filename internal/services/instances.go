@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"net/url"
+)
+
+// resolveConfig fills in the credentials and base URL of the config's instance
+func (c *ConfigService) resolveConfig(config models.Config) models.Config {
+	if config.Instance == "" {
+		// Not migrated, e.g. an invalid project URL, the credentials are still on the config
+		return config
+	}
+	for i := range c.configFile.Instances {
+		instance := &c.configFile.Instances[i]
+		if instance.Name == config.Instance {
+			config.BaseURL = instance.BaseURL
+			config.Token = instance.Token
+			config.AuthType = instance.AuthType
+			config.OAuth = instance.OAuth // Shared so refreshed tokens reach every project
+			break
+		}
+	}
+	return config
+}
+
+// storeCredentials moves the config's credentials to the instance of its host, creating the
+// instance if needed. Credentials given on a config of a named instance replace the
+// instance's, so a token rotated on one project applies to every project on that instance.
+// A new config only joins an instance with the same credentials, another token gets an
+// instance of its own.
+func (c *ConfigService) storeCredentials(config models.Config) models.Config {
+	baseURL, err := BaseURLFromProjectURL(config.ProjectURL)
+	if err != nil {
+		return config
+	}
+
+	if config.AuthType == "" && config.Token != "" && config.OAuth == nil {
+		config.AuthType = models.AuthTypeToken
+	}
+	idx := findInstance(c.configFile.Instances, config, baseURL)
+	if idx < 0 {
+		c.configFile.Instances = append(c.configFile.Instances, models.Instance{
+			Name:     uniqueInstanceName(c.configFile.Instances, baseURL),
+			BaseURL:  baseURL,
+			AuthType: models.AuthTypeToken,
+		})
+		idx = len(c.configFile.Instances) - 1
+	}
+
+	instance := &c.configFile.Instances[idx]
+	if config.Token != "" || config.OAuth != nil {
+		instance.Token = config.Token
+		instance.AuthType = config.AuthType
+		instance.OAuth = config.OAuth
+		if instance.AuthType == "" {
+			instance.AuthType = models.AuthTypeToken
+		}
+	}
+
+	config.Instance = instance.Name
+	stripCredentials(&config)
+	return config
+}
+
+// pruneInstances removes instances no config refers to anymore
+func (c *ConfigService) pruneInstances() {
+	used := make(map[string]bool)
+	for _, config := range c.configFile.Configs {
+		used[config.Instance] = true
+	}
+
+	var instances []models.Instance
+	for _, instance := range c.configFile.Instances {
+		if used[instance.Name] {
+			instances = append(instances, instance)
+		}
+	}
+	c.configFile.Instances = instances
+}
+
+// InstanceForURL returns the instance a project URL would use, if one exists for its host
+func (c *ConfigService) InstanceForURL(projectURL string) (models.Instance, bool) {
+	baseURL, err := BaseURLFromProjectURL(projectURL)
+	if err != nil {
+		return models.Instance{}, false
+	}
+	idx := findInstance(c.configFile.Instances, models.Config{}, baseURL)
+	if idx < 0 {
+		return models.Instance{}, false
+	}
+	return c.configFile.Instances[idx], true
+}
+
+// findInstance returns the index of the config's instance if it is on baseURL, otherwise of
+// the instance on baseURL with the same credentials, or -1. A config without credentials
+// uses the first instance on baseURL.
+func findInstance(instances []models.Instance, config models.Config, baseURL string) int {
+	for i, instance := range instances {
+		if config.Instance != "" && instance.Name == config.Instance && instance.BaseURL == baseURL {
+			return i
+		}
+	}
+	noCredentials := config.Token == "" && config.OAuth == nil
+	for i, instance := range instances {
+		if instance.BaseURL == baseURL && (noCredentials || sameCredentials(instance, config)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// uniqueInstanceName names an instance after its host, numbering hosts with several credentials
+func uniqueInstanceName(instances []models.Instance, baseURL string) string {
+	name := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		name = parsed.Host
+	}
+
+	taken := func(candidate string) bool {
+		for _, instance := range instances {
+			if instance.Name == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := name
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return candidate
+}
+
+// stripCredentials clears the fields that are stored on the instance
+func stripCredentials(config *models.Config) {
+	config.Token = ""
+	config.BaseURL = ""
+	config.AuthType = ""
+	config.OAuth = nil
+}
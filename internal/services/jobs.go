@@ -0,0 +1,94 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+)
+
+// GetJob fetches a single job
+func (g *GitLabService) GetJob(jobID int) (*models.PipelineJob, error) {
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/jobs/%d", g.projectID, jobID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get job: %s - %s", resp.Status, string(body))
+	}
+
+	var job models.PipelineJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %v", err)
+	}
+
+	return &job, nil
+}
+
+// GetJobTrace fetches the log of a job starting at a byte offset.
+// It returns true if only the bytes from offset on were sent, false if the
+// server ignored the range and sent the whole log.
+func (g *GitLabService) GetJobTrace(jobID int, offset int64) ([]byte, bool, error) {
+	var header http.Header
+	if offset > 0 {
+		header = http.Header{"Range": []string{fmt.Sprintf("bytes=%d-", offset)}}
+	}
+
+	resp, err := g.doRequestWithHeader("GET", fmt.Sprintf("/api/v4/projects/%d/jobs/%d/trace", g.projectID, jobID), nil, header)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing new since the last request
+		return nil, true, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, false, fmt.Errorf("failed to get job log: %s - %s", resp.Status, string(body))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read job log: %v", err)
+	}
+
+	return data, resp.StatusCode == http.StatusPartialContent, nil
+}
+
+// CancelJob cancels a pending or running job
+func (g *GitLabService) CancelJob(jobID int) error {
+	return g.jobAction(jobID, "cancel")
+}
+
+// RetryJob runs a finished job again, as a new job in the same pipeline
+func (g *GitLabService) RetryJob(jobID int) error {
+	return g.jobAction(jobID, "retry")
+}
+
+// PlayJob starts a manual job
+func (g *GitLabService) PlayJob(jobID int) error {
+	return g.jobAction(jobID, "play")
+}
+
+// jobAction posts one of the job actions (cancel, retry, play)
+func (g *GitLabService) jobAction(jobID int, action string) error {
+	resp, err := g.doRequest("POST", fmt.Sprintf("/api/v4/projects/%d/jobs/%d/%s", g.projectID, jobID, action), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to %s job: %s - %s", action, resp.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Notify shows a desktop notification through notify-send. It does nothing
+// where notify-send isn't installed, e.g. on macOS or over SSH.
+func Notify(title, body string, urgent bool) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return nil
+	}
+
+	urgency := "normal"
+	if urgent {
+		urgency = "critical"
+	}
+	if out, err := exec.Command(path, "--app-name=glcron", "--urgency="+urgency, title, body).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to send notification: %v - %s", err, out)
+	}
+	return nil
+}
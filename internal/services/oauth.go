@@ -0,0 +1,214 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuthScopes are the scopes requested during the device login
+const OAuthScopes = "api"
+
+// oauthRefreshMargin refreshes access tokens slightly before they actually expire
+const oauthRefreshMargin = time.Minute
+
+// Device flow polling states returned by PollDeviceLogin
+var (
+	ErrAuthorizationPending = errors.New("authorization pending")
+	ErrSlowDown             = errors.New("polling too fast")
+)
+
+// oauthRefreshMu serializes token refreshes, credentials are shared between service copies
+var oauthRefreshMu sync.Mutex
+
+// tokenResponse is the response of the OAuth token endpoint
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	CreatedAt    int64  `json:"created_at"`
+	Error        string `json:"error"`
+	ErrorDesc    string `json:"error_description"`
+}
+
+// StartDeviceLogin starts the OAuth device authorization flow on a GitLab instance
+func (g *GitLabService) StartDeviceLogin(baseURL, applicationID string) (*models.DeviceAuthorization, error) {
+	if applicationID == "" {
+		return nil, fmt.Errorf("application ID is required")
+	}
+
+	data := url.Values{}
+	data.Set("client_id", applicationID)
+	data.Set("scope", OAuthScopes)
+
+	resp, err := g.client.PostForm(strings.TrimSuffix(baseURL, "/")+"/oauth/authorize_device", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to start device login: %s - %s", resp.Status, string(body))
+	}
+
+	var auth models.DeviceAuthorization
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+		return nil, fmt.Errorf("failed to decode device authorization: %v", err)
+	}
+
+	if auth.Interval <= 0 {
+		auth.Interval = 5
+	}
+
+	return &auth, nil
+}
+
+// PollDeviceLogin exchanges a device code for tokens once the user has approved the login.
+// Returns ErrAuthorizationPending or ErrSlowDown while the user hasn't answered yet.
+func (g *GitLabService) PollDeviceLogin(baseURL, applicationID, deviceCode string) (*models.OAuthCredentials, error) {
+	data := url.Values{}
+	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+	data.Set("client_id", applicationID)
+	data.Set("device_code", deviceCode)
+
+	token, err := g.requestToken(baseURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OAuthCredentials{
+		ApplicationID: applicationID,
+		AccessToken:   token.AccessToken,
+		RefreshToken:  token.RefreshToken,
+		ExpiresAt:     tokenExpiry(token),
+	}, nil
+}
+
+// OnTokenRefresh registers a handler called after OAuth credentials were refreshed. It runs
+// on the goroutine of the request, once the refresh lock is released, and must not block.
+func (g *GitLabService) OnTokenRefresh(handler func(creds *models.OAuthCredentials)) {
+	g.onTokenRefresh = handler
+}
+
+// SnapshotOAuth copies OAuth credentials, which may be refreshed concurrently by a request
+func SnapshotOAuth(creds *models.OAuthCredentials) *models.OAuthCredentials {
+	if creds == nil {
+		return nil
+	}
+	oauthRefreshMu.Lock()
+	defer oauthRefreshMu.Unlock()
+
+	snapshot := *creds
+	return &snapshot
+}
+
+// accessToken returns the current OAuth access token
+func (g *GitLabService) accessToken() string {
+	oauthRefreshMu.Lock()
+	defer oauthRefreshMu.Unlock()
+
+	return g.oauth.AccessToken
+}
+
+// refreshOAuthToken renews the access token in place if it is about to expire
+func (g *GitLabService) refreshOAuthToken(force bool) error {
+	refreshed, err := g.renewOAuthToken(force)
+	if err != nil {
+		return err
+	}
+
+	// Called without the lock, handlers may save the config, which reads the credentials
+	if refreshed != nil && g.onTokenRefresh != nil {
+		g.onTokenRefresh(refreshed)
+	}
+	return nil
+}
+
+// renewOAuthToken renews the access token under the refresh lock. It returns a copy of the
+// refreshed credentials, nil if the token was still valid.
+func (g *GitLabService) renewOAuthToken(force bool) (*models.OAuthCredentials, error) {
+	oauthRefreshMu.Lock()
+	defer oauthRefreshMu.Unlock()
+
+	creds := g.oauth
+	if !force && time.Until(creds.ExpiresAt) > oauthRefreshMargin {
+		return nil, nil
+	}
+	if creds.RefreshToken == "" {
+		return nil, fmt.Errorf("OAuth session expired, log in again")
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("client_id", creds.ApplicationID)
+	data.Set("refresh_token", creds.RefreshToken)
+
+	token, err := g.requestToken(g.baseURL, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh OAuth token: %v", err)
+	}
+
+	creds.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		creds.RefreshToken = token.RefreshToken
+	}
+	creds.ExpiresAt = tokenExpiry(token)
+
+	snapshot := *creds
+	return &snapshot, nil
+}
+
+// requestToken calls the OAuth token endpoint and maps OAuth errors
+func (g *GitLabService) requestToken(baseURL string, data url.Values) (*tokenResponse, error) {
+	resp, err := g.client.PostForm(strings.TrimSuffix(baseURL, "/")+"/oauth/token", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %s", resp.Status)
+	}
+
+	switch token.Error {
+	case "":
+	case "authorization_pending":
+		return nil, ErrAuthorizationPending
+	case "slow_down":
+		return nil, ErrSlowDown
+	case "access_denied":
+		return nil, fmt.Errorf("login was denied")
+	case "expired_token":
+		return nil, fmt.Errorf("login code expired, start again")
+	default:
+		return nil, fmt.Errorf("%s: %s", token.Error, token.ErrorDesc)
+	}
+
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return nil, fmt.Errorf("failed to get token: %s", resp.Status)
+	}
+
+	return &token, nil
+}
+
+// tokenExpiry computes when an access token expires
+func tokenExpiry(token *tokenResponse) time.Time {
+	issuedAt := time.Now()
+	if token.CreatedAt > 0 {
+		issuedAt = time.Unix(token.CreatedAt, 0)
+	}
+	if token.ExpiresIn <= 0 {
+		// GitLab access tokens are valid for two hours
+		return issuedAt.Add(2 * time.Hour)
+	}
+	return issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second)
+}
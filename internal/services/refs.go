@@ -0,0 +1,327 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// commitSHAPattern matches full and abbreviated commit SHAs
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// RefPageSize is the number of branches or tags fetched per page by the ref pickers
+const RefPageSize = 20
+
+// ListBranches lists the branches of the project whose name contains search, one page at a time.
+// Returns the next page number, or 0 on the last page.
+func (g *GitLabService) ListBranches(search string, page int) ([]models.Branch, int, error) {
+	var branches []models.Branch
+	nextPage, err := g.listRefPage("branches", search, page, &branches)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list branches: %v", err)
+	}
+	return branches, nextPage, nil
+}
+
+// ListTags lists the tags of the project whose name contains search, newest first, one page
+// at a time. Returns the next page number, or 0 on the last page.
+func (g *GitLabService) ListTags(search string, page int) ([]models.Tag, int, error) {
+	var tags []models.Tag
+	nextPage, err := g.listRefPage("tags", search, page, &tags)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tags: %v", err)
+	}
+	return tags, nextPage, nil
+}
+
+// listRefPage fetches one page of the branches or tags of the project and decodes it into out
+func (g *GitLabService) listRefPage(collection, search string, page int, out interface{}) (int, error) {
+	if page < 1 {
+		page = 1
+	}
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(RefPageSize))
+	if search != "" {
+		params.Set("search", search)
+	}
+
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/%s?%s", g.projectID, collection, params.Encode()), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("%s - %s", resp.Status, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	// GitLab leaves X-Next-Page empty on the last page
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return nextPage, nil
+}
+
+// refTip is the part of a branch or tag returned by the API that ResolveRef needs
+type refTip struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+	Commit    struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// getRefTip fetches a branch or a tag, nil if it doesn't exist
+func (g *GitLabService) getRefTip(kind, name string) (*refTip, error) {
+	collection := "branches"
+	if kind == models.RefKindTag {
+		collection = "tags"
+	}
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/%s/%s", g.projectID, collection, url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get %s: %s - %s", kind, resp.Status, string(body))
+	}
+
+	var tip refTip
+	if err := json.NewDecoder(resp.Body).Decode(&tip); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %v", kind, err)
+	}
+	return &tip, nil
+}
+
+// ResolveRef checks that ref is a branch, a tag or a commit SHA of the repository.
+// GitLab only runs pipelines on branches and tags, so a commit resolves to a branch or tag
+// pointing to it, and fails if there is none.
+func (g *GitLabService) ResolveRef(ref string) (*models.ResolvedRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("no branch, tag or commit given")
+	}
+
+	for _, kind := range []string{models.RefKindBranch, models.RefKindTag} {
+		tip, err := g.getRefTip(kind, ref)
+		if err != nil {
+			return nil, err
+		}
+		if tip != nil {
+			return &models.ResolvedRef{Name: tip.Name, Kind: kind, SHA: tip.Commit.ID}, nil
+		}
+	}
+
+	if !commitSHAPattern.MatchString(ref) {
+		return nil, fmt.Errorf("no branch or tag named %q", ref)
+	}
+
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/commits/%s", g.projectID, ref), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, fmt.Errorf("no branch, tag or commit %q", ref)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to get commit: %s - %s", resp.Status, string(body))
+	}
+	var commit struct {
+		ID string `json:"id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&commit)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode commit: %v", err)
+	}
+
+	// Branches and tags containing the commit, to find one it is the tip of
+	resp, err = g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/repository/commits/%s/refs?type=all&per_page=100", g.projectID, commit.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get commit refs: %s - %s", resp.Status, string(body))
+	}
+
+	var refs []struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&refs); err != nil {
+		return nil, fmt.Errorf("failed to decode commit refs: %v", err)
+	}
+
+	for _, r := range refs {
+		tip, err := g.getRefTip(r.Type, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		if tip != nil && tip.Commit.ID == commit.ID {
+			return &models.ResolvedRef{Name: tip.Name, Kind: r.Type, SHA: commit.ID, Commit: true}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no branch or tag points to commit %s, GitLab only runs pipelines on branches and tags", shortSHA(commit.ID))
+}
+
+// CheckScheduleRefs checks the branch or tag of each schedule. It must exist for GitLab to run
+// the schedule, and be protected for its pipelines to get the protected CI/CD variables of the
+// project. Variables are only looked at when the token can read them. A protected variable
+// counts as used when a schedule variable overrides or references it, or .gitlab-ci.yml at the
+// ref references it. Other uses can't be seen and are only reported as possible.
+func (g *GitLabService) CheckScheduleRefs(schedules []models.Schedule) ([]models.RefCheck, error) {
+	protectedVariables, err := g.getProtectedVariableKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Schedules often share a ref
+	tips := make(map[string]*refTip)
+	ciConfigs := make(map[string][]byte)
+	var checks []models.RefCheck
+	for _, s := range schedules {
+		tip, ok := tips[s.Ref]
+		if !ok {
+			tip, err = g.findRef(s.Ref)
+			if err != nil {
+				return nil, err
+			}
+			tips[s.Ref] = tip
+		}
+
+		check := models.RefCheck{ScheduleID: s.ID, Ref: s.Ref, Missing: tip == nil}
+		if tip != nil && !tip.Protected && len(protectedVariables) > 0 {
+			ciConfig, ok := ciConfigs[s.Ref]
+			if !ok {
+				// Unreadable configurations are treated like ones without references
+				ciConfig, _ = g.GetFile(s.Ref, CIConfigPath)
+				ciConfigs[s.Ref] = ciConfig
+			}
+
+			used := usedProtectedVariables(s.Variables, ciConfig, protectedVariables)
+			if len(used) > 0 {
+				check.Unprotected = true
+				check.ProtectedVariables = used
+			} else {
+				check.MayMissVariables = true
+				check.ProtectedVariables = protectedVariables
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// usedProtectedVariables returns the protected variables a schedule overrides or references,
+// or its CI configuration references
+func usedProtectedVariables(variables []models.Variable, ciConfig []byte, protected []string) []string {
+	var used []string
+	for _, key := range protected {
+		// $KEY and ${KEY} in shell jobs, %KEY% in Windows batch jobs
+		reference := regexp.MustCompile(`\$(` + regexp.QuoteMeta(key) + `\b|\{` + regexp.QuoteMeta(key) + `\})|%` + regexp.QuoteMeta(key) + `%`)
+		isUsed := reference.Match(ciConfig)
+		for _, v := range variables {
+			if v.Key == key || reference.MatchString(v.Value) {
+				isUsed = true
+			}
+		}
+		if isUsed {
+			used = append(used, key)
+		}
+	}
+	return used
+}
+
+// findRef fetches the branch or tag a schedule runs on, nil if it doesn't exist.
+// Schedules created on recent GitLab versions store a full refs/heads/ or refs/tags/ ref.
+func (g *GitLabService) findRef(ref string) (*refTip, error) {
+	kinds := []string{models.RefKindBranch, models.RefKindTag}
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		ref, kinds = strings.TrimPrefix(ref, "refs/heads/"), kinds[:1]
+	case strings.HasPrefix(ref, "refs/tags/"):
+		ref, kinds = strings.TrimPrefix(ref, "refs/tags/"), kinds[1:]
+	}
+
+	for _, kind := range kinds {
+		tip, err := g.getRefTip(kind, ref)
+		if err != nil || tip != nil {
+			return tip, err
+		}
+	}
+	return nil, nil
+}
+
+// getProtectedVariableKeys lists the protected CI/CD variables of the project.
+// Only maintainers can read them, nil is returned when the token can't.
+func (g *GitLabService) getProtectedVariableKeys() ([]string, error) {
+	var keys []string
+	page := 1
+	for page > 0 {
+		resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/variables?page=%d&per_page=100", g.projectID, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+			resp.Body.Close()
+			return nil, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to get variables: %s - %s", resp.Status, string(body))
+		}
+
+		var variables []struct {
+			Key       string `json:"key"`
+			Protected bool   `json:"protected"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&variables)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode variables: %v", err)
+		}
+
+		for _, v := range variables {
+			if v.Protected {
+				keys = append(keys, v.Key)
+			}
+		}
+
+		// GitLab leaves X-Next-Page empty on the last page
+		page, _ = strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	}
+	return keys, nil
+}
+
+// shortSHA abbreviates a commit SHA the way GitLab shows it
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
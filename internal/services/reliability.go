@@ -0,0 +1,184 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"glcron/internal/models"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ReliabilityRuns is how many recent runs of a schedule the reliability statistics cover by default
+const ReliabilityRuns = 20
+
+// durationWorkers is how many pipelines are fetched at the same time for their duration
+const durationWorkers = 4
+
+// pipelineDurations caches the durations of finished pipelines, see pipelineDurationKey
+var pipelineDurations sync.Map
+
+// GetScheduleReliability computes statistics over the last runs of a schedule.
+// The jobs of failed runs are fetched to find the job failing most often.
+func (g *GitLabService) GetScheduleReliability(scheduleID, runs int) (*models.ScheduleReliability, error) {
+	pipelines, err := g.GetSchedulePipelines(scheduleID, runs)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.FillPipelineDurations(pipelines); err != nil {
+		return nil, err
+	}
+
+	jobFailures := make(map[string]int)
+	for _, p := range pipelines {
+		if p.Status != "failed" {
+			continue
+		}
+		jobs, err := g.getFailedJobs(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if !job.AllowFailure {
+				jobFailures[job.Name]++
+			}
+		}
+	}
+
+	reliability := ComputeReliability(pipelines, jobFailures)
+	reliability.ScheduleID = scheduleID
+	return &reliability, nil
+}
+
+// FillPipelineDurations fetches the duration of finished pipelines listed without one, like the
+// pipelines of a schedule. Pipelines GitLab has no duration for keep 0 and are left out of
+// duration statistics.
+func (g *GitLabService) FillPipelineDurations(pipelines []models.Pipeline) error {
+	var missing []int
+	for i, p := range pipelines {
+		if p.Duration > 0 || p.IsRunning() {
+			continue
+		}
+		if duration, ok := pipelineDurations.Load(g.pipelineDurationKey(p)); ok {
+			pipelines[i].Duration = duration.(int)
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	workers := make(chan struct{}, durationWorkers)
+	for _, i := range missing {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			pipeline, err := g.GetPipeline(pipelines[i].ID)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			if pipeline.IsRunning() {
+				return // Retried since it was listed
+			}
+			pipelines[i].Duration = pipeline.Duration
+			pipelineDurations.Store(g.pipelineDurationKey(pipelines[i]), pipeline.Duration)
+		}(i)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// pipelineDurationKey identifies a run of a pipeline, retrying it changes its update time
+func (g *GitLabService) pipelineDurationKey(p models.Pipeline) string {
+	key := fmt.Sprintf("%s/%d/%d", g.baseURL, g.projectID, p.ID)
+	if p.UpdatedAt != nil {
+		key += "@" + p.UpdatedAt.String()
+	}
+	return key
+}
+
+// getFailedJobs fetches the failed jobs of a pipeline
+func (g *GitLabService) getFailedJobs(pipelineID int) ([]models.PipelineJob, error) {
+	resp, err := g.doRequest("GET", fmt.Sprintf("/api/v4/projects/%d/pipelines/%d/jobs?scope[]=failed&per_page=100", g.projectID, pipelineID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get failed jobs: %s - %s", resp.Status, string(body))
+	}
+
+	var jobs []models.PipelineJob
+	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode failed jobs: %v", err)
+	}
+
+	return jobs, nil
+}
+
+// ComputeReliability computes the statistics of pipelines, newest first,
+// given how often each job failed in them
+func ComputeReliability(pipelines []models.Pipeline, jobFailures map[string]int) models.ScheduleReliability {
+	r := models.ScheduleReliability{Runs: len(pipelines)}
+
+	var durations []float64
+	streakOver := false
+	for _, p := range pipelines {
+		switch p.Status {
+		case "success":
+			r.Succeeded++
+			streakOver = true
+		case "failed":
+			r.Failed++
+			if !streakOver {
+				r.ConsecutiveFailures++
+			}
+		default:
+			continue
+		}
+		if seconds := p.DurationSeconds(); seconds > 0 {
+			durations = append(durations, seconds)
+		}
+	}
+
+	if len(durations) > 0 {
+		var total float64
+		for _, d := range durations {
+			total += d
+		}
+		r.MeanDuration = total / float64(len(durations))
+
+		// Nearest-rank percentile
+		sort.Float64s(durations)
+		rank := int(math.Ceil(0.95*float64(len(durations)))) - 1
+		r.P95Duration = durations[rank]
+	}
+
+	for name, count := range jobFailures {
+		// Ties go to the first name alphabetically so results are stable
+		if count > r.FailingJobCount || (count == r.FailingJobCount && name < r.FailingJob) {
+			r.FailingJob = name
+			r.FailingJobCount = count
+		}
+	}
+
+	return r
+}
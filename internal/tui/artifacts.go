@@ -0,0 +1,576 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ArtifactsBrowseLimit is the largest archive downloaded for browsing, bigger ones can only be downloaded
+const ArtifactsBrowseLimit = 200 << 20
+
+// Column widths for the artifact list
+const (
+	ColArtifactSize = 10
+	ColArtifactType = 12
+)
+
+// ArtifactsModel lists the artifacts of a job and browses its artifacts archive
+type ArtifactsModel struct {
+	width  int
+	height int
+
+	job          models.PipelineJob
+	returnScreen Screen // Screen the artifacts were opened from
+
+	// Archive contents, loaded when first browsed
+	archive  *services.ArtifactArchive
+	files    []models.ArtifactFile
+	loading  bool
+	browsing bool
+	dir      string // Directory shown while browsing, "" for the archive root
+
+	rows         []artifactRow
+	cursor       int
+	scrollOffset int
+
+	// Download destination
+	prompt    textinput.Model
+	prompting bool
+	saveFile  string // Archive file to save, "" for the whole archive
+}
+
+// artifactRow is a line of the list: an artifact of the job, or a file of the archive
+type artifactRow struct {
+	name     string
+	artifact *models.JobArtifact
+	file     *models.ArtifactFile // Nil for ".." while browsing
+}
+
+func NewArtifactsModel() ArtifactsModel {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.Cursor.Style = CursorStyle
+
+	return ArtifactsModel{
+		prompt: ti,
+	}
+}
+
+func (m *ArtifactsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetJob shows the artifacts of a job
+func (m *ArtifactsModel) SetJob(job models.PipelineJob, returnScreen Screen) {
+	m.job = job
+	m.returnScreen = returnScreen
+	m.Close()
+	m.files = nil
+	m.loading = false
+	m.browsing = false
+	m.dir = ""
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.prompting = false
+	m.prompt.Blur()
+	m.rebuildRows()
+}
+
+// SetArchive sets the archive loaded for browsing
+func (m *ArtifactsModel) SetArchive(jobID int, archive *services.ArtifactArchive, files []models.ArtifactFile, err error) {
+	if jobID != m.job.ID {
+		if archive != nil {
+			archive.Close()
+		}
+		return
+	}
+	m.loading = false
+	if err != nil {
+		if archive != nil {
+			archive.Close()
+		}
+		return
+	}
+	m.Close()
+	m.archive = archive
+	m.files = files
+	m.browsing = true
+	m.dir = ""
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.rebuildRows()
+}
+
+// JobID returns the ID of the job shown
+func (m *ArtifactsModel) JobID() int {
+	return m.job.ID
+}
+
+// Archive returns the artifacts archive of the job, nil until it was browsed
+func (m *ArtifactsModel) Archive() *services.ArtifactArchive {
+	return m.archive
+}
+
+// Close removes the archive downloaded for browsing
+func (m *ArtifactsModel) Close() {
+	if m.archive != nil {
+		m.archive.Close()
+		m.archive = nil
+	}
+}
+
+// IsTyping returns true while the download destination is edited
+func (m *ArtifactsModel) IsTyping() bool {
+	return m.prompting
+}
+
+// rebuildRows lists the job artifacts, or the current directory of the archive
+func (m *ArtifactsModel) rebuildRows() {
+	m.rows = nil
+
+	if !m.browsing {
+		// Archive first, the log has its own screen and the metadata is GitLab's index of the archive
+		for i := range m.job.Artifacts {
+			a := &m.job.Artifacts[i]
+			if a.FileType == "archive" {
+				m.rows = append([]artifactRow{{name: a.Filename, artifact: a}}, m.rows...)
+			} else if a.FileType != "trace" && a.FileType != "metadata" {
+				m.rows = append(m.rows, artifactRow{name: a.Filename, artifact: a})
+			}
+		}
+		m.clampCursor()
+		return
+	}
+
+	m.rows = append(m.rows, artifactRow{name: ".."})
+	var dirs, files []artifactRow
+	for i := range m.files {
+		f := &m.files[i]
+		if !strings.HasPrefix(f.Path, m.dir) || f.Path == m.dir {
+			continue
+		}
+		rest := strings.TrimSuffix(f.Path[len(m.dir):], "/")
+		if strings.Contains(rest, "/") {
+			continue // Not a direct child
+		}
+		if f.IsDir {
+			dirs = append(dirs, artifactRow{name: rest + "/", file: f})
+		} else {
+			files = append(files, artifactRow{name: rest, file: f})
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].name < dirs[j].name })
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+	m.rows = append(m.rows, dirs...)
+	m.rows = append(m.rows, files...)
+	m.clampCursor()
+}
+
+func (m *ArtifactsModel) clampCursor() {
+	if m.cursor >= len(m.rows) {
+		m.cursor = maxInt(len(m.rows)-1, 0)
+	}
+	m.adjustScroll()
+}
+
+// openDir shows a directory of the archive, selecting the entry named selected
+func (m *ArtifactsModel) openDir(dir, selected string) {
+	m.dir = dir
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.rebuildRows()
+	for i, row := range m.rows {
+		if row.name == selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// goUp leaves the current directory, or the archive at its root
+func (m *ArtifactsModel) goUp() {
+	if m.dir == "" {
+		m.browsing = false
+		m.cursor = 0
+		m.scrollOffset = 0
+		m.rebuildRows()
+		return
+	}
+	parent := path.Dir(strings.TrimSuffix(m.dir, "/"))
+	current := path.Base(strings.TrimSuffix(m.dir, "/")) + "/"
+	if parent == "." {
+		parent = ""
+	} else {
+		parent += "/"
+	}
+	m.openDir(parent, current)
+}
+
+// getVisibleRows returns how many rows can be shown
+func (m *ArtifactsModel) getVisibleRows() int {
+	// Height minus job summary, location, header row, prompt
+	return m.height - 4
+}
+
+// adjustScroll ensures the cursor is visible
+func (m *ArtifactsModel) adjustScroll() {
+	visibleRows := m.getVisibleRows()
+	if visibleRows <= 0 {
+		visibleRows = 5
+	}
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+}
+
+// startSave asks where to save an archive file, or the whole archive when file is ""
+func (m *ArtifactsModel) startSave(file string) tea.Cmd {
+	if m.job.ArchiveArtifact() == nil {
+		return actionWarning("This job has no artifacts archive")
+	}
+	m.saveFile = file
+	if file == "" {
+		m.prompt.SetValue(fmt.Sprintf("artifacts-%d.zip", m.job.ID))
+	} else {
+		m.prompt.SetValue(path.Base(file))
+	}
+	m.prompt.CursorEnd()
+	m.prompting = true
+	return m.prompt.Focus()
+}
+
+func (m ArtifactsModel) Update(msg tea.Msg) (ArtifactsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.prompting {
+			switch msg.String() {
+			case "enter":
+				m.prompting = false
+				m.prompt.Blur()
+				dest := strings.TrimSpace(m.prompt.Value())
+				if dest == "" {
+					return m, nil
+				}
+				save := saveArtifactMsg{jobID: m.job.ID, file: m.saveFile, dest: dest}
+				return m, func() tea.Msg {
+					return save
+				}
+			case "esc":
+				m.prompting = false
+				m.prompt.Blur()
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.prompt, cmd = m.prompt.Update(msg)
+				return m, cmd
+			}
+		}
+
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc":
+			if m.browsing {
+				m.goUp()
+				return m, nil
+			}
+			return m, Navigate(m.returnScreen)
+		case "backspace", "left":
+			if m.browsing {
+				m.goUp()
+			}
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.adjustScroll()
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+				m.adjustScroll()
+			}
+		case "enter", "right":
+			return m.open()
+		case "d":
+			if m.cursor >= len(m.rows) {
+				return m, nil
+			}
+			row := m.rows[m.cursor]
+			switch {
+			case row.artifact != nil && row.artifact.FileType == "archive":
+				return m, m.startSave("")
+			case row.artifact != nil:
+				return m, actionWarning("Reports can only be downloaded from GitLab")
+			case row.file != nil && !row.file.IsDir:
+				return m, m.startSave(row.file.Path)
+			}
+		case "D":
+			return m, m.startSave("")
+		}
+	}
+
+	return m, nil
+}
+
+// open browses the archive or a directory, or offers to download a file
+func (m ArtifactsModel) open() (ArtifactsModel, tea.Cmd) {
+	if m.cursor >= len(m.rows) {
+		return m, nil
+	}
+	row := m.rows[m.cursor]
+
+	if !m.browsing {
+		if row.artifact.FileType != "archive" {
+			return m, actionWarning("Reports can only be downloaded from GitLab")
+		}
+		if m.archive != nil {
+			m.browsing = true
+			m.openDir("", "")
+			return m, nil
+		}
+		if row.artifact.Size > ArtifactsBrowseLimit {
+			return m, actionWarning("Archive too large to browse, download it with d")
+		}
+		if m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, func() tea.Msg {
+			return loadArtifactsArchiveMsg{}
+		}
+	}
+
+	switch {
+	case row.file == nil:
+		m.goUp()
+	case row.file.IsDir:
+		m.openDir(row.file.Path, "")
+	default:
+		return m, m.startSave(row.file.Path)
+	}
+	return m, nil
+}
+
+func (m ArtifactsModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	// Split into left (2/3) and right (1/3) columns
+	leftWidth := (m.width * 2) / 3
+	rightWidth := m.width - leftWidth - 1
+
+	leftLines := m.renderLeftColumn(leftWidth)
+	rightLines := m.renderDetailsPanel(rightWidth)
+
+	var result []string
+	maxLines := maxInt(len(leftLines), len(rightLines))
+
+	for i := 0; i < maxLines; i++ {
+		left := ""
+		if i < len(leftLines) {
+			left = leftLines[i]
+		}
+		left = padToWidth(left, leftWidth)
+
+		right := ""
+		if i < len(rightLines) {
+			right = rightLines[i]
+		}
+		right = padToWidth(right, rightWidth)
+
+		result = append(result, left+"│"+right)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (m ArtifactsModel) renderLeftColumn(width int) []string {
+	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+	indent := "   "
+
+	var lines []string
+
+	// Job summary and location
+	icon, style := getStatusIconAndStyle(m.job.Status)
+	lines = append(lines, indent+TitleStyle.Render("Artifacts ")+fmt.Sprintf("#%d %s  ", m.job.ID, m.job.Name)+style.Render(icon+" "+m.job.Status))
+	location := GrayStyle.Render("Enter to browse the archive, d to download")
+	if m.browsing {
+		location = BlueStyle.Render("archive:/" + m.dir)
+	} else if m.loading {
+		location = YellowStyle.Render("Downloading archive...")
+	}
+	lines = append(lines, indent+location)
+
+	headerRow := indent +
+		padRight("Name", maxInt(width-1-len(indent)-ColArtifactSize-ColArtifactType, 10)) +
+		padRight("Size", ColArtifactSize) +
+		"Type"
+	lines = append(lines, headerStyle.Render(headerRow))
+
+	visibleRows := m.getVisibleRows()
+	needsScroll := NeedsScrollbar(len(m.rows), visibleRows)
+	scrollbar := RenderScrollbar(ScrollbarConfig{
+		TotalItems:   len(m.rows),
+		VisibleItems: visibleRows,
+		ScrollOffset: m.scrollOffset,
+		Height:       visibleRows,
+	})
+	nameWidth := maxInt(width-1-len(indent)-ColArtifactSize-ColArtifactType, 10)
+
+	for rowIdx := 0; rowIdx < visibleRows; rowIdx++ {
+		i := m.scrollOffset + rowIdx
+
+		scrollChar := " "
+		if needsScroll && rowIdx < len(scrollbar) {
+			scrollChar = scrollbar[rowIdx]
+		}
+
+		if i >= len(m.rows) {
+			line := ""
+			if i == 0 {
+				line = indent + GrayStyle.Render("This job kept no artifacts.")
+			}
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+			continue
+		}
+
+		row := m.rows[i]
+		size, kind := "", ""
+		switch {
+		case row.artifact != nil:
+			size, kind = formatSize(row.artifact.Size), row.artifact.FileType
+		case row.file != nil && !row.file.IsDir:
+			size, kind = formatSize(row.file.Size), "file"
+		case row.file != nil:
+			kind = "directory"
+		}
+
+		colName := padRight(truncateStr(row.name, nameWidth-2), nameWidth)
+		colSize := padRight(size, ColArtifactSize)
+		colType := padRight(kind, ColArtifactType)
+
+		if i == m.cursor {
+			plainRow := indent + colName + colSize + colType
+			lines = append(lines, padToWidth(SelectedStyle.Render(padToWidth(plainRow, width-1)), width-1)+scrollChar)
+		} else {
+			nameStyle := lipgloss.NewStyle()
+			if row.file == nil || row.file.IsDir {
+				nameStyle = BlueStyle
+			}
+			line := indent + nameStyle.Render(colName) + colSize + GrayStyle.Render(colType)
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+		}
+	}
+
+	// Download destination
+	promptLine := ""
+	if m.prompting {
+		promptLine = indent + YellowStyle.Render("Save to: ") + m.prompt.View()
+	}
+	lines = append(lines, promptLine)
+
+	return lines
+}
+
+func (m ArtifactsModel) renderDetailsPanel(width int) []string {
+	label := YellowStyle.Bold(true)
+	blue := BlueStyle
+	gray := GrayStyle
+
+	var lines []string
+
+	boxTitle := " Details "
+	titleWidth := lipgloss.Width(boxTitle)
+	borderLen := width - titleWidth - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+boxTitle+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	if m.cursor < len(m.rows) {
+		row := m.rows[m.cursor]
+		content = append(content, TitleStyle.Render(truncateStr(row.name, width-6)))
+		content = append(content, "")
+
+		switch {
+		case row.artifact != nil:
+			content = append(content, label.Render("Artifact"))
+			content = append(content, "  "+blue.Render("Type:")+" "+row.artifact.FileType)
+			content = append(content, "  "+blue.Render("Format:")+" "+row.artifact.FileFormat)
+			content = append(content, "  "+blue.Render("Size:")+" "+formatSize(row.artifact.Size))
+			content = append(content, "")
+			if row.artifact.FileType == "archive" {
+				content = append(content, "  "+gray.Render("Enter to browse, d to download"))
+			} else {
+				content = append(content, "  "+gray.Render("Reports are downloaded from GitLab"))
+			}
+		case row.file != nil:
+			content = append(content, label.Render("File"))
+			content = append(content, "  "+blue.Render("Path:")+" "+truncateStr(row.file.Path, width-14))
+			if row.file.IsDir {
+				content = append(content, "  "+gray.Render("Enter to open"))
+			} else {
+				content = append(content, "  "+blue.Render("Size:")+" "+formatSize(row.file.Size))
+				content = append(content, "")
+				content = append(content, "  "+gray.Render("Enter or d to download"))
+			}
+		}
+		content = append(content, "")
+	}
+
+	content = append(content, label.Render("Job"))
+	if m.job.ArtifactsExpireAt != nil {
+		content = append(content, "  "+blue.Render("Expires:")+" "+formatDetailTime(*m.job.ArtifactsExpireAt))
+	} else {
+		content = append(content, "  "+blue.Render("Expires:")+" "+gray.Render("never"))
+	}
+	if m.job.WebURL != "" {
+		browseURL := m.job.WebURL + "/artifacts/browse"
+		hyperlink := fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", browseURL, blue.Render("Browse in GitLab"))
+		content = append(content, "  "+hyperlink)
+	}
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+// formatSize formats a size in bytes
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"fmt"
 	"glcron/internal/models"
+	"glcron/internal/services"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,7 +18,13 @@ type ConfigFormField int
 const (
 	ConfigFieldName ConfigFormField = iota
 	ConfigFieldURL
+	ConfigFieldGroup
+	ConfigFieldTags
+	ConfigFieldRefresh
+	ConfigFieldAuth
 	ConfigFieldToken
+	ConfigFieldAppID
+	ConfigFieldLogin
 	ConfigFieldSave
 	ConfigFieldCancel
 )
@@ -26,9 +36,22 @@ type ConfigFormModel struct {
 	width        int
 	height       int
 
-	nameInput  textinput.Model
-	urlInput   textinput.Model
-	tokenInput textinput.Model
+	nameInput    textinput.Model
+	urlInput     textinput.Model
+	groupInput   textinput.Model
+	tagsInput    textinput.Model
+	refreshInput textinput.Model
+	tokenInput   textinput.Model
+	appIDInput   textinput.Model
+
+	// Instance profile the credentials are stored on
+	instance   string
+	sharedWith int // Other configs using the same instance
+
+	// OAuth device login
+	authType   string
+	oauth      *models.OAuthCredentials
+	deviceAuth *models.DeviceAuthorization // Set while waiting for the user to approve the login
 }
 
 func NewConfigFormModel() ConfigFormModel {
@@ -45,6 +68,24 @@ func NewConfigFormModel() ConfigFormModel {
 	urlInput.Width = 50
 	urlInput.Cursor.Style = CursorStyle
 
+	groupInput := textinput.New()
+	groupInput.Placeholder = "Optional, e.g. Backend"
+	groupInput.CharLimit = 50
+	groupInput.Width = 40
+	groupInput.Cursor.Style = CursorStyle
+
+	tagsInput := textinput.New()
+	tagsInput.Placeholder = "Optional, comma separated"
+	tagsInput.CharLimit = 200
+	tagsInput.Width = 50
+	tagsInput.Cursor.Style = CursorStyle
+
+	refreshInput := textinput.New()
+	refreshInput.Placeholder = fmt.Sprintf("Seconds, %d by default", int(PipelineRefreshInterval.Seconds()))
+	refreshInput.CharLimit = 4
+	refreshInput.Width = 30
+	refreshInput.Cursor.Style = CursorStyle
+
 	tokenInput := textinput.New()
 	tokenInput.Placeholder = "glpat-..."
 	tokenInput.CharLimit = 100
@@ -52,10 +93,21 @@ func NewConfigFormModel() ConfigFormModel {
 	tokenInput.EchoMode = textinput.EchoPassword
 	tokenInput.Cursor.Style = CursorStyle
 
+	appIDInput := textinput.New()
+	appIDInput.Placeholder = "OAuth application ID"
+	appIDInput.CharLimit = 100
+	appIDInput.Width = 50
+	appIDInput.Cursor.Style = CursorStyle
+
 	return ConfigFormModel{
-		nameInput:  nameInput,
-		urlInput:   urlInput,
-		tokenInput: tokenInput,
+		nameInput:    nameInput,
+		urlInput:     urlInput,
+		groupInput:   groupInput,
+		tagsInput:    tagsInput,
+		refreshInput: refreshInput,
+		tokenInput:   tokenInput,
+		appIDInput:   appIDInput,
+		authType:     models.AuthTypeToken,
 	}
 }
 
@@ -68,25 +120,97 @@ func (m *ConfigFormModel) SetConfig(config *models.Config, index int, isNew bool
 	m.isNew = isNew
 	m.configIndex = index
 
+	m.authType = models.AuthTypeToken
+	m.oauth = nil
+	m.deviceAuth = nil
+	m.appIDInput.SetValue("")
+	m.instance = ""
+	m.sharedWith = 0
+
 	if config != nil {
 		m.nameInput.SetValue(config.Name)
 		m.urlInput.SetValue(config.ProjectURL)
+		m.groupInput.SetValue(config.Group)
+		m.tagsInput.SetValue(strings.Join(config.Tags, ", "))
+		m.refreshInput.SetValue("")
+		if config.RefreshInterval > 0 {
+			m.refreshInput.SetValue(strconv.Itoa(config.RefreshInterval))
+		}
 		m.tokenInput.SetValue(config.Token)
+		m.instance = config.Instance
+		if config.UsesOAuth() {
+			m.authType = models.AuthTypeOAuth
+		}
+		if config.OAuth != nil {
+			m.oauth = services.SnapshotOAuth(config.OAuth)
+			m.appIDInput.SetValue(m.oauth.ApplicationID)
+		}
 	} else {
 		m.nameInput.SetValue("")
 		m.urlInput.SetValue("")
+		m.groupInput.SetValue("")
+		m.tagsInput.SetValue("")
+		m.refreshInput.SetValue("")
 		m.tokenInput.SetValue("")
 	}
 
+	m.blurCurrent()
 	m.focusedField = ConfigFieldName
 	m.nameInput.Focus()
 }
 
+// SetSharedWith sets how many other configs share the credentials of this config's instance
+func (m *ConfigFormModel) SetSharedWith(count int) {
+	m.sharedWith = count
+}
+
+// SetDeviceAuthorization shows the code the user has to enter on GitLab
+func (m *ConfigFormModel) SetDeviceAuthorization(auth *models.DeviceAuthorization) {
+	m.deviceAuth = auth
+}
+
+// SetOAuthCredentials stores the credentials obtained by the device login
+func (m *ConfigFormModel) SetOAuthCredentials(creds *models.OAuthCredentials) {
+	m.deviceAuth = nil
+	m.oauth = creds
+}
+
+// LoginPending returns true while a device login is waiting for approval
+func (m *ConfigFormModel) LoginPending() bool {
+	return m.deviceAuth != nil
+}
+
+// CancelLogin stops waiting for a device login
+func (m *ConfigFormModel) CancelLogin() {
+	m.deviceAuth = nil
+}
+
+// visibleFields returns the form fields in tab order for the selected auth method
+func (m *ConfigFormModel) visibleFields() []ConfigFormField {
+	fields := []ConfigFormField{ConfigFieldName, ConfigFieldURL, ConfigFieldGroup, ConfigFieldTags, ConfigFieldRefresh, ConfigFieldAuth}
+	if m.authType == models.AuthTypeOAuth {
+		fields = append(fields, ConfigFieldAppID, ConfigFieldLogin)
+	} else {
+		fields = append(fields, ConfigFieldToken)
+	}
+	return append(fields, ConfigFieldSave, ConfigFieldCancel)
+}
+
+// isInputField returns true if the focused field is a text input
+func (m *ConfigFormModel) isInputField() bool {
+	switch m.focusedField {
+	case ConfigFieldName, ConfigFieldURL, ConfigFieldGroup, ConfigFieldTags, ConfigFieldRefresh, ConfigFieldToken, ConfigFieldAppID:
+		return true
+	}
+	return false
+}
+
 func (m ConfigFormModel) Update(msg tea.Msg) (ConfigFormModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
+			m.deviceAuth = nil
 			return m, Navigate(ScreenConfigList)
 		case "tab", "down":
 			m.nextField()
@@ -100,14 +224,18 @@ func (m ConfigFormModel) Update(msg tea.Msg) (ConfigFormModel, tea.Cmd) {
 			// Only switch buttons if on button fields, otherwise pass to text input
 			if m.focusedField == ConfigFieldCancel {
 				m.focusedField = ConfigFieldSave
-			} else if m.focusedField == ConfigFieldName || m.focusedField == ConfigFieldURL || m.focusedField == ConfigFieldToken {
+			} else if m.focusedField == ConfigFieldAuth {
+				m.toggleAuthType()
+			} else if m.isInputField() {
 				return m.handleInputKey(msg)
 			}
 		case "right":
 			// Only switch buttons if on button fields, otherwise pass to text input
 			if m.focusedField == ConfigFieldSave {
 				m.focusedField = ConfigFieldCancel
-			} else if m.focusedField == ConfigFieldName || m.focusedField == ConfigFieldURL || m.focusedField == ConfigFieldToken {
+			} else if m.focusedField == ConfigFieldAuth {
+				m.toggleAuthType()
+			} else if m.isInputField() {
 				return m.handleInputKey(msg)
 			}
 		default:
@@ -119,43 +247,45 @@ func (m ConfigFormModel) Update(msg tea.Msg) (ConfigFormModel, tea.Cmd) {
 }
 
 func (m *ConfigFormModel) nextField() {
-	m.blurCurrent()
-	switch m.focusedField {
-	case ConfigFieldName:
-		m.focusedField = ConfigFieldURL
-	case ConfigFieldURL:
-		m.focusedField = ConfigFieldToken
-	case ConfigFieldToken:
-		m.focusedField = ConfigFieldSave
-	case ConfigFieldSave:
-		m.focusedField = ConfigFieldCancel
-	case ConfigFieldCancel:
-		m.focusedField = ConfigFieldName
-	}
-	m.focusCurrent()
+	m.moveFocus(1)
 }
 
 func (m *ConfigFormModel) prevField() {
+	m.moveFocus(-1)
+}
+
+// moveFocus moves the focus by delta positions through the visible fields, wrapping around
+func (m *ConfigFormModel) moveFocus(delta int) {
 	m.blurCurrent()
-	switch m.focusedField {
-	case ConfigFieldName:
-		m.focusedField = ConfigFieldCancel
-	case ConfigFieldURL:
-		m.focusedField = ConfigFieldName
-	case ConfigFieldToken:
-		m.focusedField = ConfigFieldURL
-	case ConfigFieldSave:
-		m.focusedField = ConfigFieldToken
-	case ConfigFieldCancel:
-		m.focusedField = ConfigFieldSave
+	fields := m.visibleFields()
+	idx := 0
+	for i, f := range fields {
+		if f == m.focusedField {
+			idx = i
+			break
+		}
 	}
+	m.focusedField = fields[(idx+delta+len(fields))%len(fields)]
 	m.focusCurrent()
 }
 
+func (m *ConfigFormModel) toggleAuthType() {
+	if m.authType == models.AuthTypeOAuth {
+		m.authType = models.AuthTypeToken
+		m.deviceAuth = nil
+	} else {
+		m.authType = models.AuthTypeOAuth
+	}
+}
+
 func (m *ConfigFormModel) blurCurrent() {
 	m.nameInput.Blur()
 	m.urlInput.Blur()
+	m.groupInput.Blur()
+	m.tagsInput.Blur()
+	m.refreshInput.Blur()
 	m.tokenInput.Blur()
+	m.appIDInput.Blur()
 }
 
 func (m *ConfigFormModel) focusCurrent() {
@@ -164,16 +294,33 @@ func (m *ConfigFormModel) focusCurrent() {
 		m.nameInput.Focus()
 	case ConfigFieldURL:
 		m.urlInput.Focus()
+	case ConfigFieldGroup:
+		m.groupInput.Focus()
+	case ConfigFieldTags:
+		m.tagsInput.Focus()
+	case ConfigFieldRefresh:
+		m.refreshInput.Focus()
 	case ConfigFieldToken:
 		m.tokenInput.Focus()
+	case ConfigFieldAppID:
+		m.appIDInput.Focus()
 	}
 }
 
 func (m ConfigFormModel) handleEnter() (ConfigFormModel, tea.Cmd) {
 	switch m.focusedField {
+	case ConfigFieldAuth:
+		m.toggleAuthType()
+	case ConfigFieldLogin:
+		projectURL := m.urlInput.Value()
+		appID := strings.TrimSpace(m.appIDInput.Value())
+		return m, func() tea.Msg {
+			return startDeviceLoginMsg{projectURL: projectURL, applicationID: appID}
+		}
 	case ConfigFieldSave:
 		return m.save()
 	case ConfigFieldCancel:
+		m.deviceAuth = nil
 		return m, Navigate(ScreenConfigList)
 	default:
 		m.nextField()
@@ -188,24 +335,69 @@ func (m ConfigFormModel) handleInputKey(msg tea.KeyMsg) (ConfigFormModel, tea.Cm
 		m.nameInput, cmd = m.nameInput.Update(msg)
 	case ConfigFieldURL:
 		m.urlInput, cmd = m.urlInput.Update(msg)
+	case ConfigFieldGroup:
+		m.groupInput, cmd = m.groupInput.Update(msg)
+	case ConfigFieldTags:
+		m.tagsInput, cmd = m.tagsInput.Update(msg)
+	case ConfigFieldRefresh:
+		m.refreshInput, cmd = m.refreshInput.Update(msg)
 	case ConfigFieldToken:
 		m.tokenInput, cmd = m.tokenInput.Update(msg)
+	case ConfigFieldAppID:
+		m.appIDInput, cmd = m.appIDInput.Update(msg)
 	}
 	return m, cmd
 }
 
 func (m ConfigFormModel) save() (ConfigFormModel, tea.Cmd) {
+	refreshInterval := 0
+	if value := strings.TrimSpace(m.refreshInput.Value()); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < MinRefreshInterval {
+			return m, func() tea.Msg {
+				return statusMsg{text: fmt.Sprintf("Refresh must be at least %d seconds", MinRefreshInterval), msgType: string(LogTypeWarning)}
+			}
+		}
+		refreshInterval = seconds
+	}
+
+	var oauth *models.OAuthCredentials
+	if m.authType == models.AuthTypeOAuth && m.oauth != nil {
+		creds := *m.oauth
+		oauth = &creds
+	}
+
 	return m, func() tea.Msg {
 		return saveConfigMsg{
-			index: m.configIndex,
-			name:  m.nameInput.Value(),
-			url:   m.urlInput.Value(),
-			token: m.tokenInput.Value(),
-			isNew: m.isNew,
+			index:    m.configIndex,
+			name:     m.nameInput.Value(),
+			url:      m.urlInput.Value(),
+			token:    m.tokenInput.Value(),
+			authType: m.authType,
+			oauth:    oauth,
+			group:    strings.TrimSpace(m.groupInput.Value()),
+			tags:     parseTags(m.tagsInput.Value()),
+			refresh:  refreshInterval,
+			isNew:    m.isNew,
 		}
 	}
 }
 
+// parseTags splits a comma separated tag list, dropping empty and duplicate tags
+func parseTags(value string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
 func (m ConfigFormModel) View() string {
 	leftWidth := (m.width * 3) / 5
 	rightWidth := m.width - leftWidth - 1
@@ -271,11 +463,47 @@ func (m ConfigFormModel) renderFormPanel(width int) []string {
 	content = append(content, urlLabel+" "+urlValue)
 	content = append(content, "") // Gap
 
-	// Token field - cursor shows when focused
-	tokenLabel := label.Render(padRight("  Access Token", labelWidth))
-	tokenValue := m.tokenInput.View()
-	content = append(content, tokenLabel+" "+tokenValue)
+	// Group and tags - used to organize the config list
+	groupLabel := label.Render(padRight("  Group", labelWidth))
+	content = append(content, groupLabel+" "+m.groupInput.View())
 	content = append(content, "") // Gap
+
+	tagsLabel := label.Render(padRight("  Tags", labelWidth))
+	content = append(content, tagsLabel+" "+m.tagsInput.View())
+	content = append(content, "") // Gap
+
+	// How often running pipelines are refreshed
+	refreshLabel := label.Render(padRight("  Refresh (s)", labelWidth))
+	content = append(content, refreshLabel+" "+m.refreshInput.View())
+	content = append(content, "") // Gap
+
+	// Auth method toggle
+	authLabel := label.Render(padRight("  Auth Method", labelWidth))
+	authValue := "◂ Access Token ▸"
+	if m.authType == models.AuthTypeOAuth {
+		authValue = "◂ OAuth ▸"
+	}
+	if m.focusedField == ConfigFieldAuth {
+		content = append(content, authLabel+" "+selected.Render(" "+authValue+" "))
+	} else {
+		content = append(content, authLabel+" "+authValue)
+	}
+	content = append(content, "") // Gap
+
+	if m.authType == models.AuthTypeOAuth {
+		content = append(content, m.renderOAuthFields(labelWidth)...)
+	} else {
+		// Token field - cursor shows when focused
+		tokenLabel := label.Render(padRight("  Access Token", labelWidth))
+		tokenValue := m.tokenInput.View()
+		content = append(content, tokenLabel+" "+tokenValue)
+		content = append(content, "") // Gap
+	}
+	if m.sharedWith > 0 {
+		note := fmt.Sprintf("Shared with %d other project(s) on %s, changes apply to all of them", m.sharedWith, m.instance)
+		content = append(content, strings.Repeat(" ", labelWidth+1)+GrayStyle.Render(note))
+		content = append(content, "") // Gap
+	}
 	content = append(content, "") // Extra gap before buttons
 
 	// Buttons
@@ -305,6 +533,46 @@ func (m ConfigFormModel) renderFormPanel(width int) []string {
 	return lines
 }
 
+// renderOAuthFields renders the application ID input, login button and login status
+func (m ConfigFormModel) renderOAuthFields(labelWidth int) []string {
+	label := LabelStyle
+	selected := SelectedStyle
+
+	var content []string
+
+	appIDLabel := label.Render(padRight("  Application ID", labelWidth))
+	content = append(content, appIDLabel+" "+m.appIDInput.View())
+	content = append(content, "") // Gap
+
+	loginBtn := " 🔑 Log in with GitLab "
+	if m.focusedField == ConfigFieldLogin {
+		loginBtn = selected.Render(loginBtn)
+	}
+	status := GrayStyle.Render("Not logged in")
+	if m.oauth != nil && m.oauth.AccessToken != "" {
+		status = GreenStyle.Render("✓ Logged in")
+	}
+	content = append(content, strings.Repeat(" ", labelWidth+1)+loginBtn+"  "+status)
+
+	if m.deviceAuth != nil {
+		verifyURL := m.deviceAuth.VerificationURIComplete
+		if verifyURL == "" {
+			verifyURL = m.deviceAuth.VerificationURI
+		}
+		indent := strings.Repeat(" ", labelWidth+1)
+		content = append(content, "")
+		hyperlink := fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", verifyURL, BlueStyle.Render(m.deviceAuth.VerificationURI))
+		content = append(content, indent+"Open "+hyperlink)
+		content = append(content, indent+"and enter the code "+YellowStyle.Bold(true).Render(m.deviceAuth.UserCode))
+		content = append(content, indent+GrayStyle.Render("⟳ Waiting for approval..."))
+	} else if m.oauth != nil && !m.oauth.ExpiresAt.IsZero() {
+		content = append(content, strings.Repeat(" ", labelWidth+1)+GrayStyle.Render("Access token renews automatically, last valid until "+m.oauth.ExpiresAt.Local().Format(time.Kitchen)))
+	}
+	content = append(content, "") // Gap
+
+	return content
+}
+
 func (m ConfigFormModel) renderHelpPanel(width int) []string {
 	// Use shared styles
 	heading := TitleStyle
@@ -337,15 +605,41 @@ func (m ConfigFormModel) renderHelpPanel(width int) []string {
 	content = append(content, "  "+example.Render("https://gitlab.company.com/team/repo"))
 	content = append(content, "")
 
+	content = append(content, heading.Render("Groups and Tags"))
+	content = append(content, "")
+	content = append(content, "Configs sharing a "+highlight.Render("group")+" are shown together")
+	content = append(content, "in a collapsible folder. "+highlight.Render("Tags")+" can be used")
+	content = append(content, "to filter the config list.")
+	content = append(content, "")
+
+	content = append(content, heading.Render("Refresh"))
+	content = append(content, "")
+	content = append(content, "Running pipelines are refreshed every")
+	content = append(content, highlight.Render("Refresh")+" seconds, finished ones less and")
+	content = append(content, "less often. Lower it for short pipelines,")
+	content = append(content, "raise it to spare a busy GitLab instance.")
+	content = append(content, "")
+
 	content = append(content, heading.Render("Creating an Access Token"))
 	content = append(content, "")
 	content = append(content, "1. Go to GitLab → Settings → Access Tokens")
 	content = append(content, "2. Create token with "+highlight.Render("api")+" scope")
 	content = append(content, "3. Copy and paste the token here")
 	content = append(content, "")
+	content = append(content, "Projects on the same GitLab instance share")
+	content = append(content, "one token, leave it empty to reuse it.")
+	content = append(content, "")
 	content = append(content, muted.Render("Token stored in: ~/.config/glcron/"))
 	content = append(content, "")
 
+	content = append(content, heading.Render("Logging in with OAuth"))
+	content = append(content, "")
+	content = append(content, "1. Register an application in GitLab with")
+	content = append(content, "   the "+highlight.Render("api")+" scope, not confidential")
+	content = append(content, "2. Paste its Application ID here")
+	content = append(content, "3. Log in and approve the code in the browser")
+	content = append(content, "")
+
 	content = append(content, heading.Render("Keyboard Shortcuts"))
 	content = append(content, "")
 	content = append(content, "  "+highlight.Render("↑/↓")+"       Navigate fields")
@@ -3,14 +3,27 @@ package tui
 import (
 	"fmt"
 	"glcron/internal/models"
+	"glcron/internal/services"
+	"net/url"
+	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type ConfigListModel struct {
 	configs     []models.Config
+	tokenHealth map[string]*models.TokenHealth
+	rows        []configRow
+	collapsed   map[string]bool
+	tagFilter   string
+	tagPopup    *SelectPopup
+	search      textinput.Model
+	searching   bool
+	matches     map[int]configMatch // Fuzzy matches by config index while searching
+	sortRecent  bool                // Order by last opened instead of file order
 	cursor      int
 	width       int
 	height      int
@@ -18,8 +31,31 @@ type ConfigListModel struct {
 	deleteIndex int
 }
 
+// configRow is a line of the config list, either a group header or a config
+type configRow struct {
+	group  string
+	header bool
+	count  int // Number of configs in the group, headers only
+	index  int // Index into configs, config rows only
+}
+
+// configMatch is the best fuzzy match of the search query against a config
+type configMatch struct {
+	score int
+	name  []int // Matched rune positions in the name
+	url   []int // Matched rune positions in the project URL
+}
+
 func NewConfigListModel() ConfigListModel {
-	return ConfigListModel{}
+	ti := textinput.New()
+	ti.Placeholder = "Search name, URL or host..."
+	ti.CharLimit = 60
+	ti.Width = 30
+
+	return ConfigListModel{
+		collapsed: make(map[string]bool),
+		search:    ti,
+	}
 }
 
 func (m *ConfigListModel) SetSize(width, height int) {
@@ -29,14 +65,236 @@ func (m *ConfigListModel) SetSize(width, height int) {
 
 func (m *ConfigListModel) SetItems(configs []models.Config) {
 	m.configs = configs
-	if m.cursor >= len(m.configs) && len(m.configs) > 0 {
-		m.cursor = len(m.configs) - 1
+	m.rebuildRows()
+}
+
+// SetTokenHealth sets the token health results, keyed by tokenHealthKey
+func (m *ConfigListModel) SetTokenHealth(health map[string]*models.TokenHealth) {
+	m.tokenHealth = health
+}
+
+// SetCollapsedGroups restores the folded groups saved in the config file
+func (m *ConfigListModel) SetCollapsedGroups(groups []string) {
+	m.collapsed = make(map[string]bool)
+	for _, group := range groups {
+		m.collapsed[group] = true
+	}
+	m.rebuildRows()
+}
+
+// IsSearching returns true while the search query is being typed
+func (m ConfigListModel) IsSearching() bool {
+	return m.searching
+}
+
+// rebuildRows regroups the configs matching the tag filter, keeping the cursor on the same item.
+// While a search query is set, matching configs are listed flat, best match first.
+func (m *ConfigListModel) rebuildRows() {
+	var current *configRow
+	if m.cursor >= 0 && m.cursor < len(m.rows) {
+		row := m.rows[m.cursor]
+		current = &row
+	}
+
+	var indices []int
+	for i, config := range m.configs {
+		if m.tagFilter == "" || config.HasTag(m.tagFilter) {
+			indices = append(indices, i)
+		}
+	}
+	if m.sortRecent {
+		sort.SliceStable(indices, func(a, b int) bool {
+			return openedAfter(m.configs[indices[a]], m.configs[indices[b]])
+		})
+	}
+
+	if query := strings.TrimSpace(m.search.Value()); query != "" {
+		m.rebuildSearchRows(indices, query)
+		return
+	}
+	m.matches = nil
+
+	// Configs without a group come first, then one section per group
+	var ungrouped []int
+	groups := make(map[string][]int)
+	var groupNames []string
+	for _, i := range indices {
+		config := m.configs[i]
+		if config.Group == "" {
+			ungrouped = append(ungrouped, i)
+			continue
+		}
+		if _, ok := groups[config.Group]; !ok {
+			groupNames = append(groupNames, config.Group)
+		}
+		groups[config.Group] = append(groups[config.Group], i)
+	}
+	sort.Strings(groupNames)
+
+	m.rows = nil
+	for _, i := range ungrouped {
+		m.rows = append(m.rows, configRow{index: i})
+	}
+	for _, group := range groupNames {
+		m.rows = append(m.rows, configRow{group: group, header: true, count: len(groups[group])})
+		if m.collapsed[group] {
+			continue
+		}
+		for _, i := range groups[group] {
+			m.rows = append(m.rows, configRow{group: group, index: i})
+		}
+	}
+
+	// Restore the cursor, falling back to the group header when the config got folded
+	if current != nil {
+		for i, row := range m.rows {
+			if row.header == current.header && row.group == current.group && (row.header || row.index == current.index) {
+				m.cursor = i
+				break
+			}
+			if row.header && row.group == current.group && !current.header && m.collapsed[row.group] {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// rebuildSearchRows lists the configs matching the query, ranked by score
+func (m *ConfigListModel) rebuildSearchRows(indices []int, query string) {
+	m.matches = make(map[int]configMatch)
+	var matched []int
+	for _, i := range indices {
+		if match, ok := matchConfig(m.configs[i], query); ok {
+			m.matches[i] = match
+			matched = append(matched, i)
+		}
+	}
+	// Stable sort keeps file or recently used order between equal scores
+	sort.SliceStable(matched, func(a, b int) bool {
+		return m.matches[matched[a]].score > m.matches[matched[b]].score
+	})
+
+	m.rows = nil
+	for _, i := range matched {
+		m.rows = append(m.rows, configRow{index: i})
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
 	}
 	if m.cursor < 0 {
 		m.cursor = 0
 	}
 }
 
+// matchConfig fuzzy matches the query against the config name, project URL and host
+func matchConfig(config models.Config, query string) (configMatch, bool) {
+	var best configMatch
+	found := false
+
+	// Name matches rank above URL matches of the same quality
+	if score, positions, ok := fuzzyMatch(query, config.Name); ok {
+		best = configMatch{score: score + fuzzyScoreWordStart, name: positions}
+		found = true
+	}
+
+	if u, err := url.Parse(config.ProjectURL); err == nil && u.Host != "" {
+		if score, positions, ok := fuzzyMatch(query, u.Host); ok && (!found || score > best.score) {
+			offset := len([]rune(config.ProjectURL[:strings.Index(config.ProjectURL, u.Host)]))
+			best = configMatch{score: score, url: shiftPositions(positions, offset, 0, len([]rune(config.ProjectURL)))}
+			found = true
+		}
+	}
+
+	if score, positions, ok := fuzzyMatch(query, config.ProjectURL); ok && (!found || score > best.score) {
+		best = configMatch{score: score, url: positions}
+		found = true
+	}
+
+	return best, found
+}
+
+// openedAfter returns true if config a was opened more recently than b, never opened configs last
+func openedAfter(a, b models.Config) bool {
+	if a.LastOpenedAt == nil {
+		return false
+	}
+	if b.LastOpenedAt == nil {
+		return true
+	}
+	return a.LastOpenedAt.After(*b.LastOpenedAt)
+}
+
+// countConfigRows returns the number of listed configs, excluding group headers
+func (m ConfigListModel) countConfigRows() int {
+	count := 0
+	for _, row := range m.rows {
+		if !row.header {
+			count++
+		} else if m.collapsed[row.group] {
+			count += row.count
+		}
+	}
+	return count
+}
+
+// selectedConfig returns the config under the cursor and its index, if the cursor is on a config
+func (m ConfigListModel) selectedConfig() (models.Config, int, bool) {
+	if m.cursor >= len(m.rows) || m.rows[m.cursor].header {
+		return models.Config{}, 0, false
+	}
+	idx := m.rows[m.cursor].index
+	return m.configs[idx], idx, true
+}
+
+// toggleGroup folds or unfolds the group under the cursor and persists the folded groups
+func (m *ConfigListModel) toggleGroup(collapse bool) tea.Cmd {
+	if m.cursor >= len(m.rows) || m.rows[m.cursor].group == "" {
+		return nil
+	}
+	group := m.rows[m.cursor].group
+	if m.collapsed[group] == collapse {
+		return nil
+	}
+	if collapse {
+		m.collapsed[group] = true
+	} else {
+		delete(m.collapsed, group)
+	}
+	m.rebuildRows()
+
+	var groups []string
+	for g := range m.collapsed {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	return func() tea.Msg {
+		return collapsedGroupsChangedMsg{groups: groups}
+	}
+}
+
+// allTags returns the sorted, de-duplicated tags of all configs
+func (m ConfigListModel) allTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, config := range m.configs {
+		for _, tag := range config.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
 func (m ConfigListModel) Update(msg tea.Msg) (ConfigListModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -69,32 +327,122 @@ func (m ConfigListModel) Update(msg tea.Msg) (ConfigListModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			case "esc":
+				m.searching = false
+				m.search.Blur()
+				m.search.SetValue("")
+				m.rebuildRows()
+				return m, nil
+			case "up":
+				if m.cursor > 0 {
+					m.cursor--
+				}
+				return m, nil
+			case "down":
+				if m.cursor < len(m.rows)-1 {
+					m.cursor++
+				}
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.search, cmd = m.search.Update(msg)
+				m.cursor = 0
+				m.rebuildRows()
+				return m, cmd
+			}
+		}
+
+		// Handle tag filter popup
+		if m.tagPopup != nil {
+			switch msg.String() {
+			case "up", "k":
+				m.tagPopup.MoveUp()
+			case "down", "j":
+				m.tagPopup.MoveDown()
+			case "enter":
+				m.tagFilter = ""
+				if m.tagPopup.SelectedIndex() > 0 {
+					m.tagFilter = m.tagPopup.Selected()
+				}
+				m.tagPopup = nil
+				m.rebuildRows()
+			case "esc":
+				m.tagPopup = nil
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
 			}
 		case "down", "j":
-			if m.cursor < len(m.configs)-1 {
+			if m.cursor < len(m.rows)-1 {
 				m.cursor++
 			}
 		case "enter":
-			if m.cursor < len(m.configs) {
+			if m.cursor < len(m.rows) && m.rows[m.cursor].header {
+				return m, m.toggleGroup(!m.collapsed[m.rows[m.cursor].group])
+			}
+			if _, idx, ok := m.selectedConfig(); ok {
 				return m, func() tea.Msg {
-					return selectConfigMsg{index: m.cursor}
+					return selectConfigMsg{index: idx}
+				}
+			}
+		case " ":
+			if m.cursor < len(m.rows) {
+				return m, m.toggleGroup(!m.collapsed[m.rows[m.cursor].group])
+			}
+		case "left":
+			return m, m.toggleGroup(true)
+		case "right":
+			return m, m.toggleGroup(false)
+		case "t":
+			tags := m.allTags()
+			if len(tags) == 0 {
+				return m, nil
+			}
+			m.tagPopup = NewSelectPopup("Filter by tag", append([]string{"All tags"}, tags...))
+			for i, tag := range tags {
+				if tag == m.tagFilter {
+					m.tagPopup.SetCursor(i + 1)
 				}
 			}
+		case "/":
+			m.searching = true
+			m.search.Focus()
+			return m, textinput.Blink
+		case "esc":
+			if m.search.Value() != "" {
+				m.search.SetValue("")
+				m.rebuildRows()
+			}
+		case "s":
+			m.sortRecent = !m.sortRecent
+			m.rebuildRows()
 		case "c":
 			return m, Navigate(ScreenNewConfig)
+		case "D":
+			return m, Navigate(ScreenDashboard)
+		case "b":
+			if config, _, ok := m.selectedConfig(); ok {
+				return m, NavigateToBrowseProjects(&config)
+			}
+			return m, NavigateToBrowseProjects(nil)
 		case "e":
-			if m.cursor < len(m.configs) {
-				config := m.configs[m.cursor]
-				return m, NavigateToEditConfig(&config, m.cursor)
+			if config, idx, ok := m.selectedConfig(); ok {
+				return m, NavigateToEditConfig(&config, idx)
 			}
 		case "d":
-			if m.cursor < len(m.configs) {
-				config := m.configs[m.cursor]
-				m.deleteIndex = m.cursor
+			if config, idx, ok := m.selectedConfig(); ok {
+				m.deleteIndex = idx
 				m.deletePopup = NewConfirmPopup(
 					"Delete Config",
 					fmt.Sprintf("Delete \"%s\"?", config.Name),
@@ -110,6 +458,9 @@ func (m ConfigListModel) View() string {
 	if m.deletePopup != nil {
 		return m.deletePopup.View(m.width, m.height)
 	}
+	if m.tagPopup != nil {
+		return m.tagPopup.View(m.width, m.height)
+	}
 
 	if len(m.configs) == 0 {
 		return m.renderEmptyState()
@@ -172,25 +523,120 @@ func (m ConfigListModel) renderEmptyState() string {
 func (m ConfigListModel) renderTable(width int) []string {
 	// Column widths
 	const colName = 25
+	const colTags = 20
 
 	var lines []string
 	indent := "   "
 
+	showTags := len(m.allTags()) > 0
+	highlight := YellowStyle.Bold(true).Underline(true)
+
+	// Search row with the active tag filter and sort order
+	searchRow := indent + TitleStyle.Render("🔍 ") + m.search.View()
+	status := fmt.Sprintf("  %d/%d", m.countConfigRows(), len(m.configs))
+	if m.tagFilter != "" {
+		status += "  tag: " + m.tagFilter
+	}
+	if m.sortRecent {
+		status += "  sort: recent"
+	}
+	lines = append(lines, searchRow+GrayStyle.Render(status))
+	lines = append(lines, "")
+
 	// Table header
-	headerRow := indent + padRight("Name", colName) + "Project URL"
+	headerRow := indent + padRight("Name", colName)
+	if showTags {
+		headerRow += padRight("Tags", colTags)
+	}
+	headerRow += "Project URL"
 	lines = append(lines, TitleStyle.Render(headerRow))
 
+	if len(m.rows) == 0 {
+		lines = append(lines, "")
+		if m.search.Value() != "" {
+			lines = append(lines, GrayStyle.Render(indent+"No configurations match, press Esc to clear the search"))
+		} else {
+			lines = append(lines, GrayStyle.Render(indent+"No configurations tagged \""+m.tagFilter+"\", press t to change the filter"))
+		}
+		return lines
+	}
+
+	// Keep the cursor within the visible rows
+	visibleRows := m.height - 3
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	start := 0
+	if m.cursor >= visibleRows {
+		start = m.cursor - visibleRows + 1
+	}
+	end := minInt(start+visibleRows, len(m.rows))
+
 	// Table rows
-	for i, config := range m.configs {
-		name := padRight(truncateStr(config.Name, colName-2), colName)
-		url := truncateURL(config.ProjectURL, width-colName-5)
-		row := indent + name + url
+	for i := start; i < end; i++ {
+		row := m.rows[i]
+
+		if row.header {
+			arrow := "▾ "
+			if m.collapsed[row.group] {
+				arrow = "▸ "
+			}
+			text := padRight(" "+arrow+row.group+fmt.Sprintf(" (%d)", row.count), width)
+			if i == m.cursor {
+				lines = append(lines, SelectedStyle.Render(text))
+			} else {
+				lines = append(lines, BlueStyle.Bold(true).Render(text))
+			}
+			continue
+		}
+
+		config := m.configs[row.index]
+		nameWidth := colName
+		if row.group != "" {
+			// Indent configs below their group header
+			nameWidth -= 2
+		}
+		name := padRight(truncateStr(config.Name, nameWidth-2), nameWidth)
+		if row.group != "" {
+			name = "  " + name
+		}
+		tags := ""
+		urlWidth := width - colName - 5
+		if showTags {
+			tags = padRight(truncateStr(strings.Join(config.Tags, ","), colTags-2), colTags)
+			urlWidth -= colTags
+		}
+		url := truncateURL(config.ProjectURL, urlWidth)
+
+		// Flag configs whose token needs attention
+		marker := indent
+		if m.tokenHealth[tokenHealthKey(config)].HasWarnings() {
+			marker = " ⚠ "
+		}
 
 		if i == m.cursor {
-			lines = append(lines, SelectedStyle.Render(row))
-		} else {
-			lines = append(lines, row)
+			lines = append(lines, SelectedStyle.Render(marker+name+tags+url))
+			continue
+		}
+
+		// Highlight the characters matched by the search
+		if match, ok := m.matches[row.index]; ok {
+			shown := len([]rune(strings.TrimRight(name, " ")))
+			if shown < len([]rune(config.Name)) {
+				shown -= 3 // Truncated with "..."
+			}
+			name = highlightPositions(name, shiftPositions(match.name, 0, 0, shown), highlight)
+
+			// The URL is truncated from the left, keeping its tail after "..."
+			shownURL := len([]rune(url))
+			full := len([]rune(config.ProjectURL))
+			min := 0
+			if shownURL < full {
+				min = 3
+			}
+			url = highlightPositions(url, shiftPositions(match.url, shownURL-full, min, shownURL), highlight)
 		}
+		lines = append(lines, YellowStyle.Render(marker)+name+GrayStyle.Render(tags)+url)
 	}
 
 	return lines
@@ -216,12 +662,26 @@ func (m ConfigListModel) renderDetailsPanel(width int) []string {
 	var content []string
 	contentWidth := width - 6 // Account for borders and padding
 
-	if m.cursor < len(m.configs) {
-		config := m.configs[m.cursor]
-
+	if m.cursor < len(m.rows) && m.rows[m.cursor].header {
+		content = append(content, m.renderGroupDetails(m.rows[m.cursor].group)...)
+	} else if config, _, ok := m.selectedConfig(); ok {
 		content = append(content, title.Render(config.Name))
 		content = append(content, "")
 
+		// Group and tags
+		if config.Group != "" {
+			content = append(content, label.Render("Group"))
+			content = append(content, "  "+config.Group)
+			content = append(content, "")
+		}
+		if len(config.Tags) > 0 {
+			content = append(content, label.Render("Tags"))
+			for _, line := range wrapText(strings.Join(config.Tags, ", "), contentWidth-2) {
+				content = append(content, "  "+line)
+			}
+			content = append(content, "")
+		}
+
 		// Project URL - wrap if too long
 		content = append(content, label.Render("Project URL"))
 		url := config.ProjectURL
@@ -235,15 +695,41 @@ func (m ConfigListModel) renderDetailsPanel(width int) []string {
 		}
 		content = append(content, "")
 
+		// Instance profile holding the credentials
+		if config.Instance != "" {
+			content = append(content, label.Render("Instance"))
+			shared := ""
+			if n := countInstanceUsers(m.configs, config.Instance); n > 1 {
+				shared = GrayStyle.Render(fmt.Sprintf(" · shared by %d projects", n))
+			}
+			content = append(content, "  "+config.Instance+shared)
+			content = append(content, "")
+		}
+
+		// Last opened
+		if config.LastOpenedAt != nil {
+			content = append(content, label.Render("Last Opened"))
+			content = append(content, "  "+config.LastOpenedAt.Local().Format("2006-01-02 15:04")+GrayStyle.Render(" ("+formatTimeAgo(*config.LastOpenedAt)+")"))
+			content = append(content, "")
+		}
+
 		// Token
 		content = append(content, label.Render("Token"))
 		maskedToken := "****"
-		if len(config.Token) > 8 {
+		if config.UsesOAuth() {
+			maskedToken = "OAuth login"
+			if creds := services.SnapshotOAuth(config.OAuth); creds == nil || creds.AccessToken == "" {
+				maskedToken = RedStyle.Render("OAuth login required")
+			}
+		} else if len(config.Token) > 8 {
 			maskedToken = config.Token[:4] + "..." + config.Token[len(config.Token)-4:]
 		}
 		content = append(content, "  "+maskedToken)
 		content = append(content, "")
 
+		// Token health - scopes, expiry and role
+		content = append(content, m.renderTokenHealth(config, contentWidth)...)
+
 		// Project ID - show "Not fetched" if 0
 		content = append(content, label.Render("Project ID"))
 		if config.ProjectID > 0 {
@@ -269,6 +755,75 @@ func (m ConfigListModel) renderDetailsPanel(width int) []string {
 	return lines
 }
 
+// renderGroupDetails renders the details panel content for a group header
+func (m ConfigListModel) renderGroupDetails(group string) []string {
+	label := YellowStyle.Bold(true)
+
+	var content []string
+	content = append(content, TitleStyle.Render(group))
+	content = append(content, "")
+
+	content = append(content, label.Render("Projects"))
+	for _, config := range m.configs {
+		if config.Group == group && (m.tagFilter == "" || config.HasTag(m.tagFilter)) {
+			content = append(content, "  "+config.Name)
+		}
+	}
+	content = append(content, "")
+
+	if m.collapsed[group] {
+		content = append(content, GrayStyle.Render("Collapsed, press Space to expand"))
+	} else {
+		content = append(content, GrayStyle.Render("Press Space to collapse"))
+	}
+
+	return content
+}
+
+// renderTokenHealth renders the token health section of the details panel
+func (m ConfigListModel) renderTokenHealth(config models.Config, contentWidth int) []string {
+	label := YellowStyle.Bold(true)
+	blue := BlueStyle
+
+	var content []string
+	content = append(content, label.Render("Token Health"))
+
+	health, ok := m.tokenHealth[tokenHealthKey(config)]
+	if !ok || health == nil {
+		content = append(content, GrayStyle.Render("  Checking..."))
+		content = append(content, "")
+		return content
+	}
+
+	if health.Scopes != nil {
+		content = append(content, "  "+blue.Render("Scopes:")+" "+strings.Join(health.Scopes, ", "))
+	}
+	if health.ExpiresAt != nil {
+		content = append(content, "  "+blue.Render("Expires:")+" "+health.ExpiresAt.Format("2006-01-02"))
+	} else if health.Scopes != nil {
+		content = append(content, "  "+blue.Render("Expires:")+" never")
+	}
+	if health.Username != "" {
+		content = append(content, "  "+blue.Render("Role:")+" "+models.AccessLevelName(health.AccessLevel)+" (@"+health.Username+")")
+	}
+
+	if len(health.Warnings) == 0 {
+		content = append(content, "  "+GreenStyle.Render("✓ OK"))
+	}
+	for _, warning := range health.Warnings {
+		for i, line := range wrapText(warning, contentWidth-4) {
+			prefix := "  ⚠ "
+			if i > 0 {
+				prefix = "    "
+			}
+			content = append(content, YellowStyle.Render(prefix+line))
+		}
+	}
+	content = append(content, "")
+
+	return content
+}
+
 func truncateURL(s string, maxLen int) string {
 	if maxLen < 6 {
 		return s
@@ -278,4 +833,3 @@ func truncateURL(s string, maxLen int) string {
 	}
 	return "..." + s[len(s)-maxLen+3:]
 }
-
@@ -0,0 +1,519 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DashboardModel shows the schedules of all configured projects in one table
+type DashboardModel struct {
+	configs      []models.Config
+	rows         []dashboardRow // All loaded schedules, in config order
+	filtered     []dashboardRow
+	failed       map[int]error // Load errors by config index
+	pending      int           // Projects still loading
+	generation   int           // Incremented on every reload to drop late results
+	cursor       int
+	scrollOffset int
+	width        int
+	height       int
+	search       textinput.Model
+	searching    bool
+	sortMode     ScheduleSort
+}
+
+// dashboardRow is a schedule together with the project it belongs to
+type dashboardRow struct {
+	configIdx int
+	project   string
+	schedule  models.Schedule
+}
+
+// dashboardCounts aggregates the schedules shown on the dashboard
+type dashboardCounts struct {
+	active   int
+	failing  int // Last pipeline failed
+	disabled int
+	unowned  int // Owner missing, blocked or deactivated
+}
+
+func NewDashboardModel() DashboardModel {
+	ti := textinput.New()
+	ti.Placeholder = "Search..."
+	ti.CharLimit = 40
+	ti.Width = 30
+
+	return DashboardModel{
+		search: ti,
+	}
+}
+
+func (m *DashboardModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Reset clears the table before loading the configs again and returns the new load generation
+func (m *DashboardModel) Reset(configs []models.Config) int {
+	m.generation++
+	m.configs = configs
+	m.rows = nil
+	m.failed = make(map[int]error)
+	m.pending = len(configs)
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.applyFilter()
+	return m.generation
+}
+
+// SetProjectSchedules adds the schedules of one project as its load completes
+func (m *DashboardModel) SetProjectSchedules(msg dashboardProjectLoadedMsg) {
+	if msg.generation != m.generation || msg.configIdx >= len(m.configs) {
+		return
+	}
+	m.pending--
+
+	if msg.err != nil {
+		m.failed[msg.configIdx] = msg.err
+	}
+	for _, s := range msg.schedules {
+		m.rows = append(m.rows, dashboardRow{
+			configIdx: msg.configIdx,
+			project:   m.configs[msg.configIdx].Name,
+			schedule:  s,
+		})
+	}
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		return m.rows[i].configIdx < m.rows[j].configIdx
+	})
+
+	// Keep the cursor on the same schedule while results arrive
+	selected, ok := m.selectedRow()
+	m.applyFilter()
+	if ok {
+		for i, row := range m.filtered {
+			if row.configIdx == selected.configIdx && row.schedule.ID == selected.schedule.ID {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.adjustScroll()
+}
+
+// IsLoading returns true while some projects have not answered yet
+func (m *DashboardModel) IsLoading() bool {
+	return m.pending > 0
+}
+
+// IsSearching returns true while the search field has focus
+func (m *DashboardModel) IsSearching() bool {
+	return m.searching
+}
+
+func (m *DashboardModel) selectedRow() (dashboardRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return dashboardRow{}, false
+	}
+	return m.filtered[m.cursor], true
+}
+
+// applyFilter rebuilds the visible rows from the search query and sort mode
+func (m *DashboardModel) applyFilter() {
+	query := strings.ToLower(m.search.Value())
+
+	filtered := make([]dashboardRow, 0, len(m.rows))
+	for _, row := range m.rows {
+		if query == "" || scheduleMatches(&row.schedule, query) ||
+			strings.Contains(strings.ToLower(row.project), query) {
+			filtered = append(filtered, row)
+		}
+	}
+	if m.sortMode != SortDefault {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return lessSchedule(&filtered[i].schedule, &filtered[j].schedule, m.sortMode)
+		})
+	}
+	m.filtered = filtered
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = maxInt(len(m.filtered)-1, 0)
+	}
+}
+
+// counts aggregates the visible schedules
+func (m *DashboardModel) counts() dashboardCounts {
+	var c dashboardCounts
+	for _, row := range m.filtered {
+		s := row.schedule
+		if s.Active {
+			c.active++
+		} else {
+			c.disabled++
+		}
+		if s.LastPipeline != nil && s.LastPipeline.Status == "failed" {
+			c.failing++
+		}
+		if s.Owner.HasLeft() {
+			c.unowned++
+		}
+	}
+	return c
+}
+
+// getVisibleRows returns how many schedule rows can be shown
+func (m *DashboardModel) getVisibleRows() int {
+	// Height minus search row, counts row, empty line, header row
+	return m.height - 4
+}
+
+// adjustScroll ensures the cursor is visible
+func (m *DashboardModel) adjustScroll() {
+	visibleRows := m.getVisibleRows()
+	if visibleRows <= 0 {
+		visibleRows = 5
+	}
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+
+	// Handle wrap-around
+	if m.cursor == 0 {
+		m.scrollOffset = 0
+	}
+	if m.cursor == len(m.filtered)-1 && len(m.filtered) > visibleRows {
+		m.scrollOffset = len(m.filtered) - visibleRows
+	}
+}
+
+func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.searching {
+			switch msg.String() {
+			case "enter", "esc":
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.search, cmd = m.search.Update(msg)
+				m.applyFilter()
+				m.cursor = 0
+				m.scrollOffset = 0
+				return m, cmd
+			}
+		}
+
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			} else if len(m.filtered) > 0 {
+				m.cursor = len(m.filtered) - 1 // wrap to last
+			}
+			m.adjustScroll()
+		case "down", "j":
+			if m.cursor < len(m.filtered)-1 {
+				m.cursor++
+			} else if len(m.filtered) > 0 {
+				m.cursor = 0 // wrap to first
+			}
+			m.adjustScroll()
+		case "enter":
+			// Open the project of the selected schedule
+			if row, ok := m.selectedRow(); ok {
+				return m, func() tea.Msg {
+					return selectConfigMsg{index: row.configIdx}
+				}
+			}
+		case "/":
+			m.searching = true
+			m.search.Focus()
+			return m, textinput.Blink
+		case "s":
+			m.sortMode = m.sortMode.Next()
+			if m.sortMode == SortReliability {
+				// Statistics are only loaded for the open project
+				m.sortMode = m.sortMode.Next()
+			}
+			m.applyFilter()
+			m.adjustScroll()
+		case "u":
+			return m, func() tea.Msg {
+				return refreshDashboardMsg{}
+			}
+		case "o", "esc":
+			return m, Navigate(ScreenConfigList)
+		}
+	}
+
+	return m, nil
+}
+
+func (m DashboardModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	// Split into left (2/3) and right (1/3) columns
+	leftWidth := (m.width * 2) / 3
+	rightWidth := m.width - leftWidth - 1
+
+	leftLines := m.renderLeftColumn(leftWidth)
+	rightLines := m.renderDetailsPanel(rightWidth)
+
+	var result []string
+	maxLines := maxInt(len(leftLines), len(rightLines))
+
+	for i := 0; i < maxLines; i++ {
+		left := ""
+		if i < len(leftLines) {
+			left = leftLines[i]
+		}
+		left = padToWidth(left, leftWidth)
+
+		right := ""
+		if i < len(rightLines) {
+			right = rightLines[i]
+		}
+		right = padToWidth(right, rightWidth)
+
+		result = append(result, left+"│"+right)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (m DashboardModel) renderLeftColumn(width int) []string {
+	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+	grayStyle := GrayStyle
+	yellowStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+
+	const (
+		colActive      = 3
+		colProject     = 22
+		colDescription = 36
+		colCron        = 15
+		colBranch      = 14
+		colStatus      = 8
+		colNext        = 8
+	)
+
+	var lines []string
+	indent := "   "
+
+	// Search row
+	searchIcon := headerStyle.Render("🔍 ")
+	counter := grayStyle.Render(fmt.Sprintf("  %d/%d", len(m.filtered), len(m.rows)))
+	if m.sortMode != SortDefault {
+		counter += grayStyle.Render("  sorted by " + m.sortMode.String())
+	}
+	lines = append(lines, indent+searchIcon+m.search.View()+counter)
+
+	// Aggregated counts
+	c := m.counts()
+	summary := indent +
+		GreenStyle.Render(fmt.Sprintf("● %d active", c.active)) + "   " +
+		RedStyle.Render(fmt.Sprintf("● %d failing", c.failing)) + "   " +
+		grayStyle.Render(fmt.Sprintf("○ %d disabled", c.disabled)) + "   " +
+		yellowStyle.Render(fmt.Sprintf("? %d unowned", c.unowned))
+	if m.IsLoading() {
+		summary += grayStyle.Render(fmt.Sprintf("   loading %d/%d projects...", len(m.configs)-m.pending, len(m.configs)))
+	}
+	if len(m.failed) > 0 {
+		summary += RedStyle.Render(fmt.Sprintf("   %d project(s) failed", len(m.failed)))
+	}
+	lines = append(lines, summary)
+	lines = append(lines, "")
+
+	headerRow := indent +
+		padRight("", colActive) +
+		padRight("Project", colProject) +
+		padRight("Description", colDescription) +
+		padRight("Cron", colCron) +
+		padRight("Branch", colBranch) +
+		padRight("Status", colStatus) +
+		padRight("Next", colNext)
+	lines = append(lines, headerStyle.Render(headerRow))
+
+	visibleRows := m.getVisibleRows()
+	needsScroll := NeedsScrollbar(len(m.filtered), visibleRows)
+	scrollbar := RenderScrollbar(ScrollbarConfig{
+		TotalItems:   len(m.filtered),
+		VisibleItems: visibleRows,
+		ScrollOffset: m.scrollOffset,
+		Height:       visibleRows,
+	})
+
+	for rowIdx := 0; rowIdx < visibleRows; rowIdx++ {
+		i := m.scrollOffset + rowIdx
+
+		scrollChar := " "
+		if needsScroll && rowIdx < len(scrollbar) {
+			scrollChar = scrollbar[rowIdx]
+		}
+
+		if i >= len(m.filtered) {
+			lines = append(lines, padToWidth("", width-1)+scrollChar)
+			continue
+		}
+
+		row := m.filtered[i]
+		schedule := row.schedule
+
+		activeIcon := "○"
+		activeStyle := grayStyle
+		if schedule.Active {
+			activeIcon = "●"
+			activeStyle = GreenStyle
+		}
+		statusIcon, statusStyle := scheduleStatusIcon(&schedule)
+
+		nextRun := "-"
+		if schedule.NextRunAt != nil {
+			nextRun = formatRelativeTime(*schedule.NextRunAt)
+		}
+
+		colActiveStr := padRight(activeIcon, colActive)
+		colProjectStr := padRight(truncateStr(row.project, colProject-2), colProject)
+		colDescStr := padRight(truncateStr(schedule.Description, colDescription-2), colDescription)
+		colCronStr := padRight(truncateStr(schedule.Cron, colCron-2), colCron)
+		colBranchStr := padRight(truncateStr(schedule.Ref, colBranch-2), colBranch)
+		colStatusStr := padRight(statusIcon, colStatus)
+		colNextStr := padRight(truncateStr(nextRun, colNext-2), colNext)
+
+		if i == m.cursor {
+			plainRow := indent + colActiveStr + colProjectStr + colDescStr + colCronStr + colBranchStr + colStatusStr + colNextStr
+			lines = append(lines, padToWidth(SelectedStyle.Render(plainRow), width-1)+scrollChar)
+		} else {
+			line := indent +
+				activeStyle.Render(colActiveStr) +
+				BlueStyle.Render(colProjectStr) +
+				colDescStr +
+				colCronStr +
+				colBranchStr +
+				statusStyle.Render(colStatusStr) +
+				colNextStr
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+		}
+	}
+
+	return lines
+}
+
+func (m DashboardModel) renderDetailsPanel(width int) []string {
+	label := YellowStyle.Bold(true)
+	blue := BlueStyle
+	gray := GrayStyle
+
+	var lines []string
+
+	boxTitle := " Details "
+	titleWidth := lipgloss.Width(boxTitle)
+	borderLen := width - titleWidth - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+boxTitle+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	if row, ok := m.selectedRow(); ok {
+		s := row.schedule
+
+		content = append(content, TitleStyle.Render(truncateStr(s.Description, width-6)))
+		content = append(content, "")
+
+		content = append(content, label.Render("Project"))
+		content = append(content, "  "+row.project)
+		content = append(content, "  "+gray.Render(truncateURL(m.configs[row.configIdx].ProjectURL, width-8)))
+		content = append(content, "")
+
+		content = append(content, label.Render("Schedule"))
+		status := GreenStyle.Render("● Active")
+		if !s.Active {
+			status = RedStyle.Render("○ Inactive")
+		}
+		content = append(content, "  "+status)
+		content = append(content, "  "+blue.Render("Cron:")+" "+s.Cron+" "+gray.Render(s.CronTimezone))
+		nextRun := "Not scheduled"
+		if s.NextRunAt != nil {
+			nextRun = formatDetailTime(*s.NextRunAt)
+		}
+		content = append(content, "  "+blue.Render("Next run:")+" "+nextRun)
+		content = append(content, "  "+blue.Render("Branch:")+" "+s.Ref)
+		content = append(content, "")
+
+		content = append(content, label.Render("Last Pipeline"))
+		if s.LastPipeline == nil || s.LastPipeline.Status == "" {
+			content = append(content, "  "+gray.Render("○ No pipeline"))
+		} else {
+			icon, style := scheduleStatusIcon(&s)
+			content = append(content, "  "+style.Render(icon+" "+s.LastPipeline.Status))
+			if s.LastPipeline.WebURL != "" {
+				linkText := fmt.Sprintf("Pipeline #%d", s.LastPipeline.ID)
+				hyperlink := fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", s.LastPipeline.WebURL, blue.Render(linkText))
+				content = append(content, "  "+hyperlink)
+			}
+		}
+		content = append(content, "")
+
+		content = append(content, label.Render("Owner"))
+		ownerInfo := "Unknown"
+		if s.Owner.Username != "" {
+			ownerInfo = fmt.Sprintf("%s (@%s)", s.Owner.Name, s.Owner.Username)
+		}
+		if s.Owner.HasLeft() {
+			content = append(content, "  "+YellowStyle.Render("? "+ownerInfo+" - no active owner"))
+		} else {
+			content = append(content, "  "+ownerInfo)
+		}
+	} else if m.IsLoading() {
+		content = append(content, gray.Render("Loading schedules..."))
+	} else {
+		content = append(content, gray.Render("No schedules"))
+	}
+
+	// Projects that could not be loaded
+	if len(m.failed) > 0 {
+		indexes := make([]int, 0, len(m.failed))
+		for idx := range m.failed {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+
+		content = append(content, "")
+		content = append(content, RedStyle.Bold(true).Render("Failed to load"))
+		for _, idx := range indexes {
+			content = append(content, "  "+m.configs[idx].Name)
+			for _, line := range wrapText(m.failed[idx].Error(), width-10) {
+				content = append(content, "    "+gray.Render(line))
+			}
+		}
+	}
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
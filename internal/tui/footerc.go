@@ -36,7 +36,13 @@ func (f *Footer) GetItems(screen Screen) []FooterItem {
 		return []FooterItem{
 			{Key: "↑↓", Description: "Navigate"},
 			{Key: "Enter", Description: "Select"},
+			{Key: "/", Description: "Search"},
+			{Key: "s", Description: "Sort"},
+			{Key: "Space", Description: "Fold"},
+			{Key: "t", Description: "Tags"},
 			{Key: "c", Description: "Create"},
+			{Key: "b", Description: "Browse"},
+			{Key: "D", Description: "Dashboard"},
 			{Key: "e", Description: "Edit"},
 			{Key: "d", Description: "Delete"},
 			{Key: "h", Description: "Help"},
@@ -47,11 +53,15 @@ func (f *Footer) GetItems(screen Screen) []FooterItem {
 		return []FooterItem{
 			// {Key: "↑↓", Description: "Navigate"},
 			{Key: "/", Description: "Search"},
+			{Key: "s", Description: "Sort"},
+			{Key: "Space", Description: "Select"},
+			{Key: "b", Description: "Bulk"},
 			// {Key: "e", Description: "Edit"},
 			{Key: "c", Description: "Create"},
 			{Key: "y", Description: "Yonk"},
 			{Key: "d", Description: "Delete"},
 			{Key: "r", Description: "Run Pipeline"},
+			{Key: "p", Description: "History"},
 			{Key: "R", Description: "Quick Run"},
 			{Key: "A", Description: "Toggle"},
 			{Key: "t", Description: "Take ownership"},
@@ -82,8 +92,84 @@ func (f *Footer) GetItems(screen Screen) []FooterItem {
 	case ScreenQuickRun:
 		return []FooterItem{
 			{Key: "R", Description: "New Run"},
+			{Key: "r", Description: "Run Again"},
 			{Key: "u", Description: "Update"},
 			{Key: "↑↓", Description: "Navigate"},
+			{Key: "Enter", Description: "Jobs"},
+			{Key: "l", Description: "Log"},
+			{Key: "x/t", Description: "Cancel/Retry"},
+			{Key: "f", Description: "Filter"},
+			{Key: "w", Description: "Watch"},
+			{Key: "h", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+			{Key: "q", Description: "Quit"},
+		}
+
+	case ScreenBrowseProjects:
+		return []FooterItem{
+			{Key: "Enter", Description: "Open"},
+			{Key: "Space", Description: "Select"},
+			{Key: "a", Description: "All"},
+			{Key: "/", Description: "Search"},
+			{Key: "Ctrl+S", Description: "Add"},
+			{Key: "Ctrl+H", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+		}
+
+	case ScreenDashboard:
+		return []FooterItem{
+			{Key: "↑↓", Description: "Navigate"},
+			{Key: "Enter", Description: "Open Project"},
+			{Key: "/", Description: "Search"},
+			{Key: "s", Description: "Sort"},
+			{Key: "u", Description: "Update"},
+			{Key: "h", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+			{Key: "q", Description: "Quit"},
+		}
+
+	case ScreenPipelineDetail:
+		return []FooterItem{
+			{Key: "↑↓", Description: "Navigate"},
+			{Key: "Enter", Description: "Log"},
+			{Key: "a", Description: "Artifacts"},
+			{Key: "x/t/p", Description: "Cancel/Retry/Play"},
+			{Key: "X/T", Description: "Pipeline"},
+			{Key: "u", Description: "Update"},
+			{Key: "h", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+			{Key: "q", Description: "Quit"},
+		}
+
+	case ScreenJobLog:
+		return []FooterItem{
+			{Key: "↑↓", Description: "Scroll"},
+			{Key: "Enter", Description: "Fold"},
+			{Key: "/", Description: "Search"},
+			{Key: "n/N", Description: "Next/Prev"},
+			{Key: "f", Description: "Follow"},
+			{Key: "a", Description: "Artifacts"},
+			{Key: "←→", Description: "Pan"},
+			{Key: "h", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+		}
+
+	case ScreenScheduleHistory:
+		return []FooterItem{
+			{Key: "↑↓", Description: "Navigate"},
+			{Key: "Enter", Description: "Jobs"},
+			{Key: "u", Description: "Update"},
+			{Key: "h", Description: "Help"},
+			{Key: "Esc", Description: "Back"},
+			{Key: "q", Description: "Quit"},
+		}
+
+	case ScreenArtifacts:
+		return []FooterItem{
+			{Key: "↑↓", Description: "Navigate"},
+			{Key: "Enter", Description: "Open"},
+			{Key: "d", Description: "Download"},
+			{Key: "D", Description: "Whole Archive"},
 			{Key: "h", Description: "Help"},
 			{Key: "Esc", Description: "Back"},
 			{Key: "q", Description: "Quit"},
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fuzzy match scoring
+const (
+	fuzzyScoreMatch       = 1  // Each matched character
+	fuzzyScoreConsecutive = 5  // Character directly follows the previous match
+	fuzzyScoreWordStart   = 8  // Character starts a word, e.g. after "/" or "-"
+	fuzzyScoreSubstring   = 20 // The whole pattern appears as is
+)
+
+// fuzzyMatch reports whether all characters of pattern appear in text in order, ignoring case.
+// It returns a score, higher is better, and the rune positions of the matched characters.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	// Prefer a contiguous match, searching for the one starting on a word boundary
+	var positions []int
+	substring := false
+	for start := 0; start+len(p) <= len(t); start++ {
+		if string(t[start:start+len(p)]) != string(p) {
+			continue
+		}
+		if positions == nil || isWordStart(t, start) {
+			positions = positions[:0]
+			for i := range p {
+				positions = append(positions, start+i)
+			}
+			substring = true
+		}
+		if isWordStart(t, start) {
+			break
+		}
+	}
+
+	// Otherwise take the leftmost subsequence
+	if !substring {
+		ti := 0
+		for _, r := range p {
+			for ti < len(t) && t[ti] != r {
+				ti++
+			}
+			if ti == len(t) {
+				return 0, nil, false
+			}
+			positions = append(positions, ti)
+			ti++
+		}
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += fuzzyScoreMatch
+		if i > 0 && positions[i-1] == pos-1 {
+			score += fuzzyScoreConsecutive
+		}
+		if isWordStart(t, pos) {
+			score += fuzzyScoreWordStart
+		}
+	}
+	if substring {
+		score += fuzzyScoreSubstring
+	}
+	// Earlier matches and shorter texts rank slightly higher
+	score -= positions[0] / 4
+	score -= len(t) / 20
+
+	return score, positions, true
+}
+
+// isWordStart returns true if the rune at pos begins a word
+func isWordStart(t []rune, pos int) bool {
+	if pos == 0 {
+		return true
+	}
+	prev := t[pos-1]
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
+
+// highlightPositions renders the runes of text at the given positions with style
+func highlightPositions(text string, positions []int, style lipgloss.Style) string {
+	if len(positions) == 0 {
+		return text
+	}
+
+	marked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		marked[pos] = true
+	}
+
+	var sb strings.Builder
+	for i, r := range []rune(text) {
+		if marked[i] {
+			sb.WriteString(style.Render(string(r)))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// shiftPositions offsets positions by delta, dropping those outside [min, max)
+func shiftPositions(positions []int, delta, min, max int) []int {
+	var shifted []int
+	for _, pos := range positions {
+		pos += delta
+		if pos >= min && pos < max {
+			shifted = append(shifted, pos)
+		}
+	}
+	return shifted
+}
@@ -73,6 +73,18 @@ func (m *HelpModel) GetHelpSections() []HelpSection {
 		return m.getConfigFormHelp()
 	case ScreenQuickRun:
 		return m.getQuickRunHelp()
+	case ScreenBrowseProjects:
+		return m.getBrowseProjectsHelp()
+	case ScreenDashboard:
+		return m.getDashboardHelp()
+	case ScreenPipelineDetail:
+		return m.getPipelineDetailHelp()
+	case ScreenJobLog:
+		return m.getJobLogHelp()
+	case ScreenScheduleHistory:
+		return m.getScheduleHistoryHelp()
+	case ScreenArtifacts:
+		return m.getArtifactsHelp()
 	default:
 		return m.getGeneralHelp()
 	}
@@ -88,10 +100,28 @@ func (m *HelpModel) getConfigListHelp() []HelpSection {
 				{Key: "Enter", Description: "Select configuration"},
 			},
 		},
+		{
+			Title: "Search",
+			Items: []HelpItem{
+				{Key: "/", Description: "Fuzzy search name, URL or host"},
+				{Key: "Esc", Description: "Clear search"},
+				{Key: "s", Description: "Toggle recently used order"},
+			},
+		},
+		{
+			Title: "Groups",
+			Items: []HelpItem{
+				{Key: "Space", Description: "Collapse/expand group"},
+				{Key: "←/→", Description: "Collapse/expand group"},
+				{Key: "t", Description: "Filter by tag"},
+			},
+		},
 		{
 			Title: "Actions",
 			Items: []HelpItem{
 				{Key: "n", Description: "New configuration"},
+				{Key: "b", Description: "Browse GitLab and add projects"},
+				{Key: "D", Description: "Dashboard of all projects"},
 				{Key: "e", Description: "Edit configuration"},
 				{Key: "d", Description: "Delete configuration"},
 			},
@@ -122,15 +152,29 @@ func (m *HelpModel) getScheduleListHelp() []HelpSection {
 				{Key: "n", Description: "New schedule"},
 				{Key: "e", Description: "Edit schedule"},
 				{Key: "d", Description: "Delete schedule"},
-				{Key: "Space", Description: "Toggle active/inactive"},
+				{Key: "A", Description: "Toggle active/inactive"},
 				{Key: "r", Description: "Run pipeline now"},
+				{Key: "w", Description: "Watch started pipelines until they finish"},
+				{Key: "p", Description: "Pipelines triggered by the schedule"},
 				{Key: "o", Description: "Take ownership"},
 			},
 		},
+		{
+			Title: "Selection",
+			Items: []HelpItem{
+				{Key: "Space", Description: "Select schedule"},
+				{Key: "V", Description: "Start/end selecting a range"},
+				{Key: "Ctrl+A", Description: "Select all matching the search"},
+				{Key: "b", Description: "Bulk actions on the selection"},
+				{Key: "Esc", Description: "Clear the selection"},
+			},
+		},
 		{
 			Title: "Other",
 			Items: []HelpItem{
 				{Key: "R", Description: "Quick Run (ad-hoc pipeline)"},
+				{Key: "/", Description: "Search schedules"},
+				{Key: "s", Description: "Sort by next run, status, reliability or description"},
 				{Key: "u", Description: "Refresh list"},
 				{Key: "h", Description: "Show this help"},
 				{Key: "Esc", Description: "Back to configs"},
@@ -195,8 +239,16 @@ func (m *HelpModel) getQuickRunHelp() []HelpSection {
 			Title: "Pipeline List",
 			Items: []HelpItem{
 				{Key: "↑/k", Description: "Move up"},
-				{Key: "↓/j", Description: "Move down"},
+				{Key: "↓/j", Description: "Move down, loads older pipelines at the end"},
 				{Key: "R", Description: "Open run form"},
+				{Key: "r", Description: "Run the pipeline again, with its ref and variables"},
+				{Key: "f", Description: "Filter by branch, status, source, user or date"},
+				{Key: "F", Description: "Clear the filter"},
+				{Key: "Enter", Description: "Show jobs of the pipeline"},
+				{Key: "l", Description: "Show the log of the failed or running job"},
+				{Key: "x", Description: "Cancel pipeline (asks first)"},
+				{Key: "t", Description: "Retry failed jobs of the pipeline"},
+				{Key: "w", Description: "Watch started pipelines until they finish"},
 				{Key: "u", Description: "Refresh pipeline list"},
 			},
 		},
@@ -205,7 +257,18 @@ func (m *HelpModel) getQuickRunHelp() []HelpSection {
 			Items: []HelpItem{
 				{Key: "Tab", Description: "Next field"},
 				{Key: "Shift+Tab", Description: "Previous field"},
-				{Key: "Enter", Description: "Open branch selector / Start pipeline"},
+				{Key: "Enter", Description: "Open branch or option selector / Start pipeline"},
+				{Key: "←/→", Description: "Pick the previous/next option"},
+				{Key: "Esc", Description: "Close form"},
+			},
+		},
+		{
+			Title: "Filter Form",
+			Items: []HelpItem{
+				{Key: "Tab", Description: "Next field"},
+				{Key: "←/→", Description: "Change status or source"},
+				{Key: "Enter", Description: "Apply / Clear filters"},
+				{Key: "Ctrl+S", Description: "Apply filters"},
 				{Key: "Esc", Description: "Close form"},
 			},
 		},
@@ -220,6 +283,184 @@ func (m *HelpModel) getQuickRunHelp() []HelpSection {
 	}
 }
 
+func (m *HelpModel) getBrowseProjectsHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Connect",
+			Items: []HelpItem{
+				{Key: "Tab", Description: "Next field"},
+				{Key: "Enter", Description: "Connect to the instance"},
+			},
+		},
+		{
+			Title: "Browse",
+			Items: []HelpItem{
+				{Key: "↑/k", Description: "Move up"},
+				{Key: "↓/j", Description: "Move down, loads more at the end"},
+				{Key: "Enter", Description: "Open group / Select project"},
+				{Key: "/", Description: "Search on the server"},
+				{Key: "Esc", Description: "Back to groups / connect"},
+			},
+		},
+		{
+			Title: "Select",
+			Items: []HelpItem{
+				{Key: "Space", Description: "Select project"},
+				{Key: "a", Description: "Select all loaded projects"},
+				{Key: "Ctrl+S", Description: "Add selected projects"},
+			},
+		},
+	}
+}
+
+func (m *HelpModel) getDashboardHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Navigation",
+			Items: []HelpItem{
+				{Key: "↑/k", Description: "Move up"},
+				{Key: "↓/j", Description: "Move down"},
+				{Key: "Enter", Description: "Open the schedule's project"},
+			},
+		},
+		{
+			Title: "Search",
+			Items: []HelpItem{
+				{Key: "/", Description: "Search project, description, branch or cron"},
+				{Key: "s", Description: "Sort by next run, status or description"},
+			},
+		},
+		{
+			Title: "General",
+			Items: []HelpItem{
+				{Key: "u", Description: "Reload all projects"},
+				{Key: "h", Description: "Show this help"},
+				{Key: "Esc", Description: "Back to configs"},
+				{Key: "q", Description: "Quit application"},
+			},
+		},
+	}
+}
+
+func (m *HelpModel) getPipelineDetailHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Jobs",
+			Items: []HelpItem{
+				{Key: "↑/k", Description: "Previous job"},
+				{Key: "↓/j", Description: "Next job"},
+				{Key: "Enter/l", Description: "Show the job log"},
+				{Key: "a", Description: "Show the job artifacts"},
+				{Key: "x", Description: "Cancel job (asks first)"},
+				{Key: "t", Description: "Retry job"},
+				{Key: "p", Description: "Start manual job"},
+				{Key: "u", Description: "Refresh, running pipelines refresh on their own"},
+			},
+		},
+		{
+			Title: "Pipeline",
+			Items: []HelpItem{
+				{Key: "X", Description: "Cancel pipeline (asks first)"},
+				{Key: "T", Description: "Retry failed jobs of the pipeline"},
+			},
+		},
+		{
+			Title: "General",
+			Items: []HelpItem{
+				{Key: "h", Description: "Show this help"},
+				{Key: "Esc", Description: "Go back"},
+				{Key: "q", Description: "Quit application"},
+			},
+		},
+	}
+}
+
+func (m *HelpModel) getScheduleHistoryHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Pipelines",
+			Items: []HelpItem{
+				{Key: "↑/k", Description: "Newer pipeline"},
+				{Key: "↓/j", Description: "Older pipeline"},
+				{Key: "Enter", Description: "Show jobs of the pipeline"},
+				{Key: "u", Description: "Refresh"},
+			},
+		},
+		{
+			Title: "General",
+			Items: []HelpItem{
+				{Key: "h", Description: "Show this help"},
+				{Key: "Esc", Description: "Back to schedule list"},
+				{Key: "q", Description: "Quit application"},
+			},
+		},
+	}
+}
+
+func (m *HelpModel) getJobLogHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Scrolling",
+			Items: []HelpItem{
+				{Key: "↑↓/jk", Description: "Move line by line"},
+				{Key: "PgUp/PgDn", Description: "Move page by page"},
+				{Key: "g/G", Description: "Go to start/end"},
+				{Key: "←/→", Description: "Pan long lines"},
+			},
+		},
+		{
+			Title: "Log",
+			Items: []HelpItem{
+				{Key: "Enter/Space", Description: "Collapse/expand section"},
+				{Key: "/", Description: "Search"},
+				{Key: "n/N", Description: "Next/previous match"},
+				{Key: "f", Description: "Follow a running job"},
+				{Key: "u", Description: "Reload the whole log"},
+				{Key: "a", Description: "Show the job artifacts"},
+			},
+		},
+		{
+			Title: "General",
+			Items: []HelpItem{
+				{Key: "h", Description: "Show this help"},
+				{Key: "Esc", Description: "Clear search / Go back"},
+				{Key: "q", Description: "Quit application"},
+			},
+		},
+	}
+}
+
+func (m *HelpModel) getArtifactsHelp() []HelpSection {
+	return []HelpSection{
+		{
+			Title: "Navigation",
+			Items: []HelpItem{
+				{Key: "↑/k", Description: "Previous item"},
+				{Key: "↓/j", Description: "Next item"},
+				{Key: "Enter/→", Description: "Browse the archive / Open directory"},
+				{Key: "Backspace/←", Description: "Parent directory"},
+			},
+		},
+		{
+			Title: "Download",
+			Items: []HelpItem{
+				{Key: "d", Description: "Download the selected file"},
+				{Key: "D", Description: "Download the whole archive"},
+				{Key: "Enter", Description: "Save to the path typed"},
+				{Key: "Esc", Description: "Cancel the download"},
+			},
+		},
+		{
+			Title: "General",
+			Items: []HelpItem{
+				{Key: "h", Description: "Show this help"},
+				{Key: "Esc", Description: "Parent directory / Go back"},
+				{Key: "q", Description: "Quit application"},
+			},
+		},
+	}
+}
+
 func (m *HelpModel) getGeneralHelp() []HelpSection {
 	return []HelpSection{
 		{
@@ -319,6 +560,18 @@ func (m *HelpModel) getScreenName() string {
 		return "New Configuration"
 	case ScreenQuickRun:
 		return "Quick Pipeline Run"
+	case ScreenBrowseProjects:
+		return "Browse Projects"
+	case ScreenDashboard:
+		return "Dashboard"
+	case ScreenPipelineDetail:
+		return "Pipeline Jobs"
+	case ScreenJobLog:
+		return "Job Log"
+	case ScreenScheduleHistory:
+		return "Schedule History"
+	case ScreenArtifacts:
+		return "Artifacts"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,565 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// JobLogFollowInterval is how often the log of a running job is polled in follow mode
+const JobLogFollowInterval = 3 * time.Second
+
+// Log viewer layout
+const (
+	jobLogGutter      = 8 // Cursor marker, line number and a space
+	jobLogScrollStep  = 8 // Columns per left/right key press
+	jobLogPageOverlap = 2 // Lines kept on screen when paging
+)
+
+// JobLogModel shows the log of a job, with collapsible sections, search and follow mode
+type JobLogModel struct {
+	width  int
+	height int
+
+	job          models.PipelineJob
+	returnScreen Screen
+	loaded       bool
+
+	raw       string
+	lines     []traceLine
+	sections  []traceSection
+	collapsed map[int]bool // Sections toggled by the user, overriding the log's default
+	visible   []int        // Indexes into lines that are not inside a collapsed section
+
+	cursor       int // Index into visible
+	scrollOffset int
+	hOffset      int // Horizontal scroll in columns
+
+	follow  bool
+	pollSeq int // Incremented when follow is turned on, to stop older polling loops
+
+	search    textinput.Model
+	searching bool
+	matches   []int // Indexes into lines matching the search
+	matchIdx  int
+}
+
+func NewJobLogModel() JobLogModel {
+	ti := textinput.New()
+	ti.Placeholder = "Search log..."
+	ti.CharLimit = 80
+	ti.Width = 40
+
+	return JobLogModel{
+		search: ti,
+	}
+}
+
+func (m *JobLogModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetJob clears the viewer for a job, remembering the screen to return to
+func (m *JobLogModel) SetJob(job models.PipelineJob, returnScreen Screen) {
+	m.job = job
+	m.returnScreen = returnScreen
+	m.loaded = false
+	m.raw = ""
+	m.lines = nil
+	m.sections = nil
+	m.collapsed = make(map[int]bool)
+	m.visible = nil
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.hOffset = 0
+	m.follow = isJobRunning(job.Status)
+	m.pollSeq++
+	m.searching = false
+	m.search.Blur()
+	m.search.SetValue("")
+	m.matches = nil
+}
+
+// SetJobStatus updates the job, follow mode stops polling once it finished
+func (m *JobLogModel) SetJobStatus(job *models.PipelineJob) {
+	if job != nil && job.ID == m.job.ID {
+		m.job = *job
+	}
+}
+
+// AppendTrace adds log data fetched from offset, or replaces the log if the server sent all of it
+func (m *JobLogModel) AppendTrace(data []byte, offset int64, partial bool) {
+	if partial {
+		if offset != m.Offset() {
+			return // Stale response, the log moved on since it was requested
+		}
+		if len(data) == 0 && m.loaded {
+			return
+		}
+		m.raw += string(data)
+	} else {
+		m.raw = string(data)
+	}
+	m.loaded = true
+
+	selected := m.selectedLine()
+	m.lines, m.sections = parseTrace(m.raw)
+	m.rebuildVisible(selected)
+	if m.search.Value() != "" {
+		m.findMatches()
+	}
+	if m.follow {
+		m.cursor = maxInt(len(m.visible)-1, 0)
+	}
+	m.adjustScroll()
+}
+
+// JobID returns the ID of the job shown
+func (m *JobLogModel) JobID() int {
+	return m.job.ID
+}
+
+// Offset returns the number of log bytes loaded, the start of the next incremental fetch
+func (m *JobLogModel) Offset() int64 {
+	return int64(len(m.raw))
+}
+
+// IsFollowing returns true while the log should be polled
+func (m *JobLogModel) IsFollowing() bool {
+	return m.follow && isJobRunning(m.job.Status)
+}
+
+// PollSeq identifies the current polling loop
+func (m *JobLogModel) PollSeq() int {
+	return m.pollSeq
+}
+
+// IsSearching returns true while the search field has focus
+func (m *JobLogModel) IsSearching() bool {
+	return m.searching
+}
+
+// isJobRunning returns true if a job with this status can still write to its log
+func isJobRunning(status string) bool {
+	switch status {
+	case "created", "waiting_for_resource", "preparing", "pending", "running":
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *JobLogModel) isCollapsed(section int) bool {
+	if collapsed, ok := m.collapsed[section]; ok {
+		return collapsed
+	}
+	return m.sections[section].collapsed
+}
+
+// isHidden returns true if the section or one of its parents is collapsed
+func (m *JobLogModel) isHidden(section int) bool {
+	for s := section; s >= 0; s = m.sections[s].parent {
+		if m.isCollapsed(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedLine returns the index into lines under the cursor, -1 if there is none
+func (m *JobLogModel) selectedLine() int {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return -1
+	}
+	return m.visible[m.cursor]
+}
+
+// rebuildVisible recomputes the shown lines, keeping the cursor on line or the closest line above it
+func (m *JobLogModel) rebuildVisible(line int) {
+	m.visible = nil
+	m.cursor = 0
+	for i, l := range m.lines {
+		if m.isHidden(l.section) {
+			continue
+		}
+		if i <= line {
+			m.cursor = len(m.visible)
+		}
+		m.visible = append(m.visible, i)
+	}
+}
+
+// toggleSection folds the section under the cursor, or the one enclosing it
+func (m *JobLogModel) toggleSection() {
+	idx := m.selectedLine()
+	if idx < 0 {
+		return
+	}
+
+	line := m.lines[idx]
+	section := line.header
+	if section < 0 {
+		section = line.section
+	}
+	if section < 0 {
+		return
+	}
+
+	m.collapsed[section] = !m.isCollapsed(section)
+	if line.header < 0 {
+		// Folding from inside a section moves to its header
+		for i, l := range m.lines {
+			if l.header == section {
+				idx = i
+				break
+			}
+		}
+	}
+	m.rebuildVisible(idx)
+	m.adjustScroll()
+}
+
+// reveal unfolds the sections around a line and moves the cursor to it
+func (m *JobLogModel) reveal(line int) {
+	for s := m.lines[line].section; s >= 0; s = m.sections[s].parent {
+		m.collapsed[s] = false
+	}
+	m.rebuildVisible(line)
+	m.adjustScroll()
+}
+
+// findMatches collects the lines containing the search query
+func (m *JobLogModel) findMatches() {
+	query := strings.ToLower(m.search.Value())
+	m.matches = nil
+	if query == "" {
+		return
+	}
+	for i, line := range m.lines {
+		if strings.Contains(strings.ToLower(line.plain), query) {
+			m.matches = append(m.matches, i)
+		}
+	}
+	if m.matchIdx >= len(m.matches) {
+		m.matchIdx = 0
+	}
+}
+
+// jumpToMatch moves to the next match after the cursor, or the previous one before it
+func (m *JobLogModel) jumpToMatch(forward bool) {
+	if len(m.matches) == 0 {
+		return
+	}
+
+	current := m.selectedLine()
+	next := -1
+	if forward {
+		next = 0
+		for i, line := range m.matches {
+			if line > current {
+				next = i
+				break
+			}
+		}
+	} else {
+		next = len(m.matches) - 1
+		for i := len(m.matches) - 1; i >= 0; i-- {
+			if m.matches[i] < current {
+				next = i
+				break
+			}
+		}
+	}
+
+	m.matchIdx = next
+	m.follow = false
+	m.reveal(m.matches[next])
+}
+
+// getVisibleRows returns how many log lines can be shown
+func (m *JobLogModel) getVisibleRows() int {
+	// Height minus top border, info row, separator, bottom border
+	return m.height - 4
+}
+
+// adjustScroll ensures the cursor is visible
+func (m *JobLogModel) adjustScroll() {
+	visibleRows := m.getVisibleRows()
+	if visibleRows <= 0 {
+		visibleRows = 5
+	}
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+	if m.scrollOffset > maxInt(len(m.visible)-visibleRows, 0) {
+		m.scrollOffset = maxInt(len(m.visible)-visibleRows, 0)
+	}
+}
+
+func (m *JobLogModel) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.adjustScroll()
+}
+
+func (m JobLogModel) Update(msg tea.Msg) (JobLogModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				m.findMatches()
+				m.jumpToMatch(true)
+				return m, nil
+			case "esc":
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.search, cmd = m.search.Update(msg)
+				return m, cmd
+			}
+		}
+
+		page := maxInt(m.getVisibleRows()-jobLogPageOverlap, 1)
+
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc":
+			if len(m.matches) > 0 || m.search.Value() != "" {
+				m.search.SetValue("")
+				m.matches = nil
+				return m, nil
+			}
+			return m, Navigate(m.returnScreen)
+		case "up", "k":
+			m.follow = false
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "pgup", "ctrl+u":
+			m.follow = false
+			m.moveCursor(-page)
+		case "pgdown", "ctrl+d", "pgdn":
+			m.moveCursor(page)
+		case "g", "home":
+			m.follow = false
+			m.moveCursor(-len(m.visible))
+		case "G", "end":
+			m.moveCursor(len(m.visible))
+		case "left":
+			m.hOffset = maxInt(m.hOffset-jobLogScrollStep, 0)
+		case "right":
+			m.hOffset += jobLogScrollStep
+		case "enter", " ":
+			m.toggleSection()
+		case "/":
+			m.searching = true
+			m.search.Focus()
+			return m, textinput.Blink
+		case "n":
+			m.jumpToMatch(true)
+		case "N":
+			m.jumpToMatch(false)
+		case "f":
+			m.follow = !m.follow
+			if m.follow {
+				m.moveCursor(len(m.visible))
+				m.pollSeq++
+				if isJobRunning(m.job.Status) {
+					return m, func() tea.Msg {
+						return loadJobTraceMsg{}
+					}
+				}
+			}
+		case "u":
+			return m, func() tea.Msg {
+				return loadJobTraceMsg{full: true}
+			}
+		case "a":
+			job := m.job
+			return m, NavigateToArtifacts(&job)
+		}
+	}
+
+	return m, nil
+}
+
+func (m JobLogModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	var lines []string
+	innerWidth := m.width - 2
+
+	// Title
+	title := fmt.Sprintf(" 📜 Job #%d %s ", m.job.ID, m.job.Name)
+	borderLen := m.width - lipgloss.Width(title) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+title+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	// Info row: status, stage, runner, follow and search state
+	icon, style := getStatusIconAndStyle(m.job.Status)
+	info := " " + style.Render(icon+" "+m.job.Status) +
+		"  " + BlueStyle.Render("Stage:") + " " + m.job.Stage +
+		"  " + BlueStyle.Render("Duration:") + " " + formatJobDuration(m.job.Duration, m.job.StartedAt)
+	if m.job.Runner != nil {
+		info += "  " + BlueStyle.Render("Runner:") + " " + runnerName(m.job.Runner)
+	}
+	switch {
+	case m.IsFollowing():
+		info += "  " + GreenStyle.Render("● following")
+	case isJobRunning(m.job.Status):
+		info += "  " + GrayStyle.Render("○ follow off (f)")
+	}
+	if m.searching {
+		info += "  " + YellowStyle.Render("/") + m.search.View()
+	} else if query := m.search.Value(); query != "" {
+		if len(m.matches) == 0 {
+			info += "  " + RedStyle.Render(fmt.Sprintf("no match for %q", query))
+		} else {
+			info += "  " + YellowStyle.Render(fmt.Sprintf("%q %d/%d", query, m.matchIdx+1, len(m.matches)))
+		}
+	}
+	lines = append(lines, "│"+padToWidth(info, innerWidth)+"│")
+	lines = append(lines, "├"+strings.Repeat("─", innerWidth)+"┤")
+
+	// Log lines
+	visibleRows := m.getVisibleRows()
+	contentWidth := innerWidth - jobLogGutter - 1
+	needsScroll := NeedsScrollbar(len(m.visible), visibleRows)
+	scrollbar := RenderScrollbar(ScrollbarConfig{
+		TotalItems:   len(m.visible),
+		VisibleItems: visibleRows,
+		ScrollOffset: m.scrollOffset,
+		Height:       visibleRows,
+	})
+
+	matched := make(map[int]bool, len(m.matches))
+	for _, line := range m.matches {
+		matched[line] = true
+	}
+
+	for rowIdx := 0; rowIdx < visibleRows; rowIdx++ {
+		i := m.scrollOffset + rowIdx
+
+		scrollChar := " "
+		if needsScroll && rowIdx < len(scrollbar) {
+			scrollChar = scrollbar[rowIdx]
+		}
+
+		content := ""
+		if i < len(m.visible) {
+			content = m.renderLine(m.visible[i], i == m.cursor, matched[m.visible[i]], contentWidth)
+		} else if i == 0 {
+			if m.loaded {
+				content = "  " + GrayStyle.Render("The log is empty.")
+			} else {
+				content = "  " + GrayStyle.Render("Loading log...")
+			}
+		}
+
+		lines = append(lines, "│"+padToWidth(content, innerWidth-1)+scrollChar+"│")
+	}
+
+	lines = append(lines, BorderBottomLeft+strings.Repeat(BorderTop, innerWidth)+BorderBottomRight)
+
+	return strings.Join(lines, "\n")
+}
+
+// renderLine renders a log line with its gutter, cut to the horizontal scroll position
+func (m JobLogModel) renderLine(idx int, selected, matched bool, width int) string {
+	line := m.lines[idx]
+
+	marker := " "
+	number := GrayStyle.Render(padLeft(fmt.Sprintf("%d", idx+1), jobLogGutter-2))
+	if selected {
+		marker = YellowStyle.Render("▶")
+		number = YellowStyle.Render(padLeft(fmt.Sprintf("%d", idx+1), jobLogGutter-2))
+	}
+
+	text := line.text
+	if matched {
+		text = highlightQuery(line.plain, m.search.Value())
+	}
+
+	if line.header >= 0 {
+		section := m.sections[line.header]
+		fold := "▾ "
+		if m.isCollapsed(line.header) {
+			fold = "▸ "
+		}
+		text = YellowStyle.Render(fold) + text
+		if duration := section.Duration(); duration > 0 {
+			text += " " + GrayStyle.Render(formatJobDuration(float64(duration), nil))
+		}
+	}
+
+	// Reset after every line so colours don't bleed into the frame
+	text = ansi.Cut(text, m.hOffset, m.hOffset+width) + "\x1b[0m"
+	return marker + number + " " + text
+}
+
+// highlightQuery marks every case-insensitive occurrence of query in text
+func highlightQuery(text, query string) string {
+	if query == "" {
+		return text
+	}
+
+	style := lipgloss.NewStyle().Background(ColorYellow).Foreground(lipgloss.Color("0"))
+	lower := strings.ToLower(text)
+	query = strings.ToLower(query)
+
+	var sb strings.Builder
+	for {
+		pos := strings.Index(lower, query)
+		if pos < 0 {
+			sb.WriteString(text)
+			break
+		}
+		sb.WriteString(text[:pos])
+		sb.WriteString(style.Render(text[pos : pos+len(query)]))
+		text = text[pos+len(query):]
+		lower = lower[pos+len(query):]
+	}
+	return sb.String()
+}
+
+// logJob picks the job whose log is most useful for a pipeline: the first failed, else a running one, else the last
+func logJob(jobs []models.PipelineJob) *models.PipelineJob {
+	if len(jobs) == 0 {
+		return nil
+	}
+	for _, status := range []string{"failed", "running"} {
+		for i := range jobs {
+			if jobs[i].Status == status {
+				return &jobs[i]
+			}
+		}
+	}
+	// Jobs come newest first
+	return &jobs[0]
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"glcron/internal/models"
+	"glcron/internal/services"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,25 +10,65 @@ import (
 
 // Data loading messages
 type configsLoadedMsg struct {
-	configs []models.Config
+	configs         []models.Config
+	collapsedGroups []string
+	watchPipelines  bool
+	lintConfig      *models.LintConfig
+	// Days before expiry tokens are flagged, 0 for the default
+	tokenExpiryWarningDays int
 }
 
 type schedulesLoadedMsg struct {
 	schedules []models.Schedule
 }
 
-type branchesLoadedMsg struct {
-	branches []string
+type validateRefMsg struct {
+	ref         string
+	forSchedule bool // Schedules can't run on a commit
+}
+
+type refResolvedMsg struct {
+	ref         *models.ResolvedRef
+	forSchedule bool
+	err         error
+}
+
+// Ref picker messages
+type loadRefsMsg struct {
+	query      string
+	branchPage int // 0 when loading tags
+	tagPage    int
+}
+
+type refsLoadedMsg struct {
+	query          string
+	branchPage     int
+	branches       []models.Branch
+	tags           []models.Tag
+	nextBranchPage int
+	nextTagPage    int
+	err            error
+}
+
+type refSearchMsg struct {
+	seq int
+}
+
+type refPickedMsg struct {
+	ref         string
+	forSchedule bool
 }
 
 type errMsg struct {
 	err error
 }
 
+// tokenRefreshedMsg is sent after a request refreshed OAuth credentials, to save them
+type tokenRefreshedMsg struct{}
+
 // Combined messages for async operations
 type configSelectedMsg struct {
 	schedules     []models.Schedule
-	branches      []string
 	updatedConfig *models.Config
 	currentUser   *models.User
 }
@@ -35,11 +76,29 @@ type configSelectedMsg struct {
 type schedulesSavedMsg struct {
 	schedules []models.Schedule
 	message   string
+	// Set when a schedule was run, its new pipeline is looked up and watched if enabled
+	ranScheduleID      int
+	previousPipelineID int
+}
+
+type schedulePipelineFoundMsg struct {
+	configIdx int
+	pipeline  *models.Pipeline
 }
 
 type configSavedMsg struct {
 	configs []models.Config
 	message string
+	// Token health of the saved config, nil if it could not be checked
+	healthKey string
+	health    *models.TokenHealth
+	// Instance of the saved config, the other configs on it share its token
+	instance string
+}
+
+type tokenHealthLoadedMsg struct {
+	healthKey string // See tokenHealthKey
+	health    *models.TokenHealth
 }
 
 // Status messages
@@ -56,6 +115,8 @@ type navigateMsg struct {
 	schedule    *models.Schedule
 	config      *models.Config
 	configIndex int
+	pipeline    *models.Pipeline
+	job         *models.PipelineJob
 }
 
 // Config actions
@@ -64,17 +125,113 @@ type selectConfigMsg struct {
 }
 
 type saveConfigMsg struct {
-	index int
-	name  string
-	url   string
-	token string
-	isNew bool
+	index    int
+	name     string
+	url      string
+	token    string
+	authType string
+	oauth    *models.OAuthCredentials
+	group    string
+	tags     []string
+	refresh  int // Seconds between refreshes of running pipelines, 0 for the default
+	isNew    bool
+}
+
+// OAuth device login messages
+type startDeviceLoginMsg struct {
+	projectURL    string
+	applicationID string
+}
+
+type deviceLoginStartedMsg struct {
+	baseURL       string
+	applicationID string
+	auth          *models.DeviceAuthorization
+}
+
+type pollDeviceLoginMsg struct {
+	baseURL       string
+	applicationID string
+	deviceCode    string
+	interval      time.Duration
+	deadline      time.Time
+}
+
+type deviceLoginCompletedMsg struct {
+	creds *models.OAuthCredentials
+}
+
+type deviceLoginPendingMsg struct {
+	next pollDeviceLoginMsg
+}
+
+type deviceLoginFailedMsg struct {
+	err error
 }
 
 type deleteConfigMsg struct {
 	index int
 }
 
+type collapsedGroupsChangedMsg struct {
+	groups []string
+}
+
+// Project browser
+type loadBrowseGroupsMsg struct {
+	baseURL string
+	token   string
+	search  string
+	page    int
+}
+
+type loadBrowseProjectsMsg struct {
+	baseURL string
+	token   string
+	groupID int // 0 lists all projects the user is a member of
+	search  string
+	page    int
+}
+
+type browseGroupsLoadedMsg struct {
+	groups   []models.Group
+	search   string
+	page     int
+	nextPage int
+}
+
+type browseProjectsLoadedMsg struct {
+	projects []models.Project
+	groupID  int
+	search   string
+	page     int
+	nextPage int
+}
+
+type browseFailedMsg struct {
+	err error
+}
+
+type addProjectsMsg struct {
+	configs []models.Config
+}
+
+type projectsAddedMsg struct {
+	configs []models.Config // All configs after adding
+	added   []models.Config
+	skipped int // Projects that were already configured
+}
+
+// Dashboard
+type refreshDashboardMsg struct{}
+
+type dashboardProjectLoadedMsg struct {
+	generation int
+	configIdx  int
+	schedules  []models.Schedule
+	err        error
+}
+
 // Schedule actions
 type saveScheduleMsg struct {
 	id          int
@@ -129,23 +286,183 @@ type ownershipTakenMsg struct {
 
 type refreshSchedulesMsg struct{}
 
+// Bulk actions on the selected schedules
+type bulkActionMsg struct {
+	action    bulkAction
+	schedules []models.Schedule
+	value     string // Ref or timezone of the change actions
+}
+
+type bulkItemDoneMsg struct {
+	index int
+	err   error
+}
+
+type cancelBulkMsg struct{}
+
+type bulkFinishedMsg struct {
+	schedules []models.Schedule
+	err       error
+}
+
+type reliabilityLoadedMsg struct {
+	configIdx   int
+	seq         int
+	reliability *models.ScheduleReliability // Nil when it failed to load
+	queue       []int                       // IDs of the schedules left to load
+}
+
+type refChecksLoadedMsg struct {
+	configIdx int
+	checks    []models.RefCheck
+}
+
 // Quick Run messages
 type quickRunPipelineMsg struct {
 	branch    string
 	variables []models.Variable
+	inputs    map[string]interface{}
+}
+
+type loadRunFieldsMsg struct {
+	ref string
+}
+
+type runFieldsLoadedMsg struct {
+	ref    string
+	fields []models.RunField
+	err    error
 }
 
 type pipelineCreatedMsg struct {
 	pipeline *models.Pipeline
 }
 
+type rerunPipelineMsg struct {
+	pipeline models.Pipeline
+}
+
+type pipelineVariablesLoadedMsg struct {
+	pipeline  models.Pipeline
+	variables []models.Variable
+}
+
 type pipelinesLoadedMsg struct {
 	pipelines []models.PipelineWithJobs
+	filter    models.PipelineFilter // Filter the page was loaded with
+	page      int
+	nextPage  int // 0 on the last page
 }
 
 type refreshPipelinesMsg struct{}
 
-type pipelineTickMsg struct{}
+type loadMorePipelinesMsg struct {
+	page int
+}
+
+type pipelinesFailedMsg struct {
+	err error
+}
+
+type pipelineFilterAppliedMsg struct {
+	filter models.PipelineFilter
+}
+
+type pipelineFilterClosedMsg struct{}
+
+type pipelinesUpdatedMsg struct {
+	pipelines []models.PipelineWithJobs // Only the pipelines that changed
+	removed   []int                     // Pipelines that no longer match the filter
+	filter    models.PipelineFilter
+	err       error
+}
+
+type pipelineTickMsg struct {
+	seq int
+}
+
+// Pipeline detail messages
+type pipelineDetailLoadedMsg struct {
+	pipeline *models.Pipeline
+	jobs     []models.PipelineJob
+	bridges  []models.PipelineBridge
+}
+
+type refreshPipelineDetailMsg struct{}
+
+type pipelineDetailTickMsg struct {
+	pipelineID int
+}
+
+// Schedule history messages
+type scheduleHistoryLoadedMsg struct {
+	scheduleID int
+	pipelines  []models.Pipeline
+}
+
+type refreshScheduleHistoryMsg struct{}
+
+// Pipeline and job actions
+type pipelineActionMsg struct {
+	action     pipelineAction
+	pipelineID int
+	jobID      int    // Job actions only
+	name       string // "pipeline #12" or "job \"build\"", for status messages
+}
+
+type pipelineActionDoneMsg struct {
+	pipelineID int
+	message    string
+}
+
+// Pipeline watch messages
+type toggleWatchMsg struct{}
+
+type pipelineWatchTickMsg struct {
+	pipelineID int
+}
+
+type pipelineWatchLoadedMsg struct {
+	pipelineID int
+	pipeline   *models.Pipeline
+	jobs       []models.PipelineJob
+	err        error
+}
+
+// Job log messages
+type loadJobTraceMsg struct {
+	full bool // Reload the whole log instead of fetching what was added
+}
+
+type jobTraceLoadedMsg struct {
+	jobID   int
+	job     *models.PipelineJob // Current job status, nil if it could not be fetched
+	data    []byte
+	offset  int64
+	partial bool
+	pollSeq int
+}
+
+type jobTraceTickMsg struct {
+	jobID   int
+	pollSeq int
+}
+
+// Artifact messages
+type loadArtifactsArchiveMsg struct{}
+
+type artifactsArchiveLoadedMsg struct {
+	jobID   int
+	archive *services.ArtifactArchive
+	files   []models.ArtifactFile
+	err     error
+}
+
+type saveArtifactMsg struct {
+	jobID int
+	file  string // Path in the archive, "" for the whole archive
+	dest  string
+}
 
 // Helper to create navigate command
 func Navigate(screen Screen) tea.Cmd {
@@ -166,6 +483,41 @@ func NavigateToEditConfig(config *models.Config, index int) tea.Cmd {
 	}
 }
 
+// NavigateToBrowseProjects opens the project browser, prefilled with the instance and token of config
+func NavigateToBrowseProjects(config *models.Config) tea.Cmd {
+	return func() tea.Msg {
+		return navigateMsg{screen: ScreenBrowseProjects, config: config}
+	}
+}
+
+// NavigateToPipeline opens the job list of a pipeline
+func NavigateToPipeline(pipeline *models.Pipeline) tea.Cmd {
+	return func() tea.Msg {
+		return navigateMsg{screen: ScreenPipelineDetail, pipeline: pipeline}
+	}
+}
+
+// NavigateToScheduleHistory opens the pipelines triggered by a schedule
+func NavigateToScheduleHistory(schedule *models.Schedule) tea.Cmd {
+	return func() tea.Msg {
+		return navigateMsg{screen: ScreenScheduleHistory, schedule: schedule}
+	}
+}
+
+// NavigateToJobLog opens the log of a job
+func NavigateToJobLog(job *models.PipelineJob) tea.Cmd {
+	return func() tea.Msg {
+		return navigateMsg{screen: ScreenJobLog, job: job}
+	}
+}
+
+// NavigateToArtifacts opens the artifacts of a job
+func NavigateToArtifacts(job *models.PipelineJob) tea.Cmd {
+	return func() tea.Msg {
+		return navigateMsg{screen: ScreenArtifacts, job: job}
+	}
+}
+
 func NavigateToYonk(schedule *models.Schedule) tea.Cmd {
 	return func() tea.Msg {
 		// Create a copy with "[Copy]" prefix
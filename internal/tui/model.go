@@ -1,9 +1,11 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"glcron/internal/models"
 	"glcron/internal/services"
+	"os"
 	"strings"
 	"time"
 
@@ -18,7 +20,16 @@ const AppName = "glcron"
 var AppVersion = "dev"
 
 // Refresh intervals
-const PipelineRefreshInterval = 55 * time.Second // Auto-refresh interval for running pipelines
+const (
+	PipelineRefreshInterval     = 15 * time.Second // Default refresh interval while pipelines run, see Config.RefreshInterval
+	PipelineIdleRefreshInterval = 2 * time.Minute  // Longest refresh interval once all pipelines finished
+)
+
+// MinRefreshInterval is the shortest refresh interval a config can set, in seconds
+const MinRefreshInterval = 5
+
+// ReliabilityLoads is how many schedules of the list have their statistics loaded at the same time
+const ReliabilityLoads = 3
 
 // Screen represents the current view
 type Screen int
@@ -31,6 +42,12 @@ const (
 	ScreenEditConfig
 	ScreenNewConfig
 	ScreenQuickRun
+	ScreenBrowseProjects
+	ScreenDashboard
+	ScreenPipelineDetail
+	ScreenJobLog
+	ScreenScheduleHistory
+	ScreenArtifacts
 )
 
 // Model is the main application model
@@ -47,8 +64,31 @@ type Model struct {
 	currentConfigIdx  int
 	schedules         []models.Schedule
 	filteredSchedules []models.Schedule
-	branches          []string
 	currentUser       *models.User
+	tokenHealth       map[string]*models.TokenHealth // Keyed by tokenHealthKey
+
+	// Days before expiry tokens are flagged, 0 for the default
+	tokenExpiryWarningDays int
+
+	// Pipelines started from glcron are followed until they finish when enabled
+	watchPipelines bool
+	watch          *pipelineWatch
+
+	// Bulk action running on the selected schedules, one at a time
+	bulk *bulkProgress
+
+	// Statistics loads of the schedule list, older ones stop
+	reliabilitySeq int
+
+	// Signaled by requests that refreshed OAuth credentials, saved from Update
+	tokenRefreshed chan struct{}
+
+	// Program output, nil when not given at startup
+	output *Output
+
+	// Command-line startup, cleared once handled
+	startup     *StartupOptions
+	startScreen Screen
 
 	// Global log panel (top-right of app)
 	log *LogPanel
@@ -59,20 +99,39 @@ type Model struct {
 	scheduleForm ScheduleFormModel
 	configForm   ConfigFormModel
 	quickRun     QuickRunModel
+	browser      ProjectBrowserModel
+	dashboard    DashboardModel
+	pipeline     PipelineDetailModel
+	jobLog       JobLogModel
+	history      ScheduleHistoryModel
+	artifacts    ArtifactsModel
 	help         HelpModel
 }
 
 // NewModel creates a new application model
-func NewModel() Model {
+func NewModel(opts StartupOptions) Model {
 	configService := services.NewConfigService()
 	gitlabService := services.NewGitLabService()
 
+	// Refreshed OAuth credentials are shared with the loaded configs. Requests run in
+	// commands, so only signal the refresh and persist it from Update.
+	tokenRefreshed := make(chan struct{}, 1)
+	gitlabService.OnTokenRefresh(func(creds *models.OAuthCredentials) {
+		select {
+		case tokenRefreshed <- struct{}{}:
+		default: // A save is already pending
+		}
+	})
+
 	m := Model{
 		configService:    configService,
 		gitlabService:    gitlabService,
+		tokenRefreshed:   tokenRefreshed,
+		output:           opts.Output,
 		screen:           ScreenConfigList,
 		currentConfigIdx: -1,
-		branches:         []string{"main", "master"},
+		tokenHealth:      make(map[string]*models.TokenHealth),
+		startup:          &opts,
 	}
 
 	m.configList = NewConfigListModel()
@@ -80,25 +139,72 @@ func NewModel() Model {
 	m.scheduleForm = NewScheduleFormModel()
 	m.configForm = NewConfigFormModel()
 	m.quickRun = NewQuickRunModel()
+	m.browser = NewProjectBrowserModel()
+	m.dashboard = NewDashboardModel()
+	m.pipeline = NewPipelineDetailModel()
+	m.jobLog = NewJobLogModel()
+	m.history = NewScheduleHistoryModel()
+	m.artifacts = NewArtifactsModel()
 	m.help = NewHelpModel()
 	m.log = NewLogPanel()
 
 	return m
 }
 
+// Close releases what the model holds on to, once the program has quit
+func (m Model) Close() {
+	m.artifacts.Close()
+}
+
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tea.EnterAltScreen,
 		m.loadConfigs,
+		m.waitForTokenRefresh(),
 	)
 }
 
+// reloadChangedConfigs shows the configs reloaded by a save that found the file changed
+// by another glcron
+func (m *Model) reloadChangedConfigs(err error) {
+	if errors.Is(err, services.ErrConfigChanged) {
+		m.reloadConfigs()
+	}
+}
+
+// reloadConfigs shows the configs of the config service, after it reloaded the file
+func (m *Model) reloadConfigs() {
+	// Stay on the open project, unless its config is gone
+	current := -1
+	if m.currentConfigIdx >= 0 && m.currentConfigIdx < len(m.configs) {
+		current = services.FindConfigByProject(m.configService.GetConfigs(), m.configs[m.currentConfigIdx].ProjectURL)
+	}
+
+	m.configs = m.configService.GetConfigs()
+	m.configList.SetCollapsedGroups(m.configService.GetCollapsedGroups())
+	m.configList.SetItems(m.configs)
+	if m.currentConfigIdx >= 0 && current < 0 {
+		m.screen = ScreenConfigList
+	}
+	m.currentConfigIdx = current
+}
+
+// waitForTokenRefresh waits until a request refreshed OAuth credentials
+func (m Model) waitForTokenRefresh() tea.Cmd {
+	tokenRefreshed := m.tokenRefreshed
+
+	return func() tea.Msg {
+		<-tokenRefreshed
+		return tokenRefreshedMsg{}
+	}
+}
+
 func (m Model) loadConfigs() tea.Msg {
 	configFile, err := m.configService.Load()
 	if err != nil {
 		return errMsg{err}
 	}
-	return configsLoadedMsg{configs: configFile.Configs}
+	return configsLoadedMsg{configs: m.configService.GetConfigs(), collapsedGroups: configFile.CollapsedGroups, watchPipelines: configFile.WatchPipelines, lintConfig: configFile.Lint, tokenExpiryWarningDays: configFile.TokenExpiryWarningDays}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -118,7 +224,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "ctrl+c", "q":
-			if m.screen == ScreenConfigList {
+			if m.screen == ScreenConfigList && (msg.String() == "ctrl+c" || !m.configList.IsSearching()) {
 				return m, tea.Quit
 			}
 		case "ctrl+h":
@@ -128,7 +234,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "h":
 			// Show help only on non-form screens (plain "h" conflicts with text input)
 			if m.screen != ScreenEditSchedule && m.screen != ScreenNewSchedule &&
-				m.screen != ScreenEditConfig && m.screen != ScreenNewConfig {
+				m.screen != ScreenEditConfig && m.screen != ScreenNewConfig &&
+				!(m.screen == ScreenConfigList && m.configList.IsSearching()) &&
+				!(m.screen == ScreenBrowseProjects && m.browser.IsTyping()) &&
+				!(m.screen == ScreenDashboard && m.dashboard.IsSearching()) &&
+				!(m.screen == ScreenJobLog && m.jobLog.IsSearching()) &&
+				!(m.screen == ScreenArtifacts && m.artifacts.IsTyping()) &&
+				!(m.screen == ScreenQuickRun && m.quickRun.IsTyping()) &&
+				!(m.screen == ScreenScheduleList && m.scheduleList.IsTyping()) {
 				m.help.Show(m.screen)
 				return m, nil
 			}
@@ -143,42 +256,84 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.scheduleForm.SetSize(m.width-2, contentHeight)
 		m.configForm.SetSize(m.width-2, contentHeight)
 		m.quickRun.SetSize(m.width-2, contentHeight)
+		m.browser.SetSize(m.width-2, contentHeight)
+		m.dashboard.SetSize(m.width-2, contentHeight)
+		m.pipeline.SetSize(m.width-2, contentHeight)
+		m.jobLog.SetSize(m.width-2, contentHeight)
+		m.history.SetSize(m.width-2, contentHeight)
+		m.artifacts.SetSize(m.width-2, contentHeight)
 		m.help.SetSize(m.width-2, contentHeight)
 
 	case configsLoadedMsg:
 		m.configs = msg.configs
+		m.configList.SetCollapsedGroups(msg.collapsedGroups)
 		m.configList.SetItems(m.configs)
+		m.watchPipelines = msg.watchPipelines
+		m.scheduleList.SetLintConfig(msg.lintConfig)
+		m.tokenExpiryWarningDays = msg.tokenExpiryWarningDays
 		m.log.Clear()
+		cmds = append(cmds, m.checkTokenHealthCmds(m.configs)...)
+		if m.startup != nil {
+			var cmd tea.Cmd
+			m, cmd = m.openStartupProject()
+			cmds = append(cmds, cmd)
+		}
+
+	case tokenHealthLoadedMsg:
+		m.tokenHealth[msg.healthKey] = msg.health
+		m.configList.SetTokenHealth(m.tokenHealth)
 
 	case schedulesLoadedMsg:
 		m.schedules = msg.schedules
 		m.filteredSchedules = msg.schedules
 		m.scheduleList.SetItems(m.filteredSchedules)
 		m.log.Clear()
+		cmds = append(cmds, m.loadReliabilityCmd(msg.schedules), m.loadRefChecksCmd(msg.schedules))
 
-	case branchesLoadedMsg:
-		m.branches = msg.branches
-		m.scheduleForm.SetBranches(m.branches)
+	case reliabilityLoadedMsg:
+		if msg.configIdx == m.currentConfigIdx && msg.seq == m.reliabilitySeq {
+			if msg.reliability != nil {
+				m.scheduleList.SetReliability(msg.reliability)
+			}
+			if len(msg.queue) > 0 {
+				cmds = append(cmds, m.loadScheduleReliabilityCmd(msg.configIdx, msg.seq, msg.queue))
+			}
+		}
+
+	case refChecksLoadedMsg:
+		if msg.configIdx == m.currentConfigIdx {
+			m.scheduleList.SetRefChecks(msg.checks)
+		}
 
 	case configSelectedMsg:
 		m.schedules = msg.schedules
 		m.filteredSchedules = msg.schedules
-		m.branches = msg.branches
 		m.currentUser = msg.currentUser
+		m.scheduleList.ClearReliability()
+		m.scheduleList.ClearRefChecks()
+		m.scheduleList.ClearSelection()
+		m.quickRun.ClearFilter()
 		m.scheduleList.SetItems(m.filteredSchedules)
 		m.scheduleList.SetCurrentUser(m.currentUser)
-		m.scheduleForm.SetBranches(m.branches)
 		m.log.Clear()
 		m.screen = ScreenScheduleList
+		reliabilityCmd := tea.Batch(m.loadReliabilityCmd(msg.schedules), m.loadRefChecksCmd(msg.schedules))
 
 		// Save config with updated ProjectID
 		if msg.updatedConfig != nil && m.currentConfigIdx >= 0 && m.currentConfigIdx < len(m.configs) {
 			m.configs[m.currentConfigIdx] = *msg.updatedConfig
 			m.configList.SetItems(m.configs)
 			// Save to file
-			configFile := &models.ConfigFile{Configs: m.configs}
-			_ = m.configService.Save(configFile)
+			m.reloadChangedConfigs(m.configService.UpdateConfig(m.currentConfigIdx, *msg.updatedConfig))
+		}
+
+		// Continue to the screen requested on the command line
+		if m.startScreen == ScreenQuickRun {
+			m.startScreen = ScreenConfigList
+			model, cmd := m.handleNavigation(navigateMsg{screen: ScreenQuickRun})
+			return model, tea.Batch(cmd, reliabilityCmd)
 		}
+		cmds = append(cmds, reliabilityCmd)
 
 	case schedulesSavedMsg:
 		m.schedules = msg.schedules
@@ -186,19 +341,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.scheduleList.SetItems(m.filteredSchedules)
 		m.log.Success(msg.message)
 		m.screen = ScreenScheduleList
-		return m, ClearStatusAfter(10 * time.Second)
+		return m, tea.Batch(ClearStatusAfter(10*time.Second), m.findSchedulePipelineCmd(msg.ranScheduleID, msg.previousPipelineID), m.loadRefChecksCmd(msg.schedules))
+
+	case schedulePipelineFoundMsg:
+		if msg.configIdx != m.currentConfigIdx {
+			return m, nil
+		}
+		cmd := m.startWatch(msg.pipeline)
+		return m, cmd
 
 	case configSavedMsg:
 		m.configs = msg.configs
 		m.configList.SetItems(m.configs)
-		m.log.Success(msg.message)
 		m.screen = ScreenConfigList
-		return m, ClearStatusAfter(10 * time.Second)
+		if msg.health != nil {
+			m.tokenHealth[msg.healthKey] = msg.health
+			m.configList.SetTokenHealth(m.tokenHealth)
+		}
+		if msg.health.HasWarnings() {
+			m.log.Warning(msg.message + " " + msg.health.Warnings[0])
+		} else {
+			m.log.Success(msg.message)
+		}
+		// The saved token may have changed for every config on the instance
+		var shared []models.Config
+		if msg.instance != "" {
+			for _, config := range m.configs {
+				if config.Instance == msg.instance && tokenHealthKey(config) != msg.healthKey {
+					shared = append(shared, config)
+				}
+			}
+		}
+		return m, tea.Batch(append(m.checkTokenHealthCmds(shared), ClearStatusAfter(10*time.Second))...)
 
 	case errMsg:
+		m.reloadChangedConfigs(msg.err)
 		m.log.Error(msg.err.Error())
 		return m, ClearStatusAfter(10 * time.Second)
 
+	case tokenRefreshedMsg:
+		reloaded, err := m.configService.SaveRefreshedCredentials()
+		if reloaded {
+			m.reloadConfigs()
+		}
+		if err != nil {
+			m.reloadChangedConfigs(err)
+			m.log.Error("Failed to save refreshed OAuth token: " + err.Error())
+			return m, tea.Batch(m.waitForTokenRefresh(), ClearStatusAfter(10*time.Second))
+		}
+		return m, m.waitForTokenRefresh()
+
 	case statusMsg:
 		m.log.Show(msg.text, LogType(msg.msgType))
 		return m, ClearStatusAfter(10 * time.Second)
@@ -240,47 +432,379 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case refreshSchedulesMsg:
 		return m.handleRefreshSchedules()
 
+	case bulkActionMsg:
+		m.bulk = &bulkProgress{bulkActionMsg: msg}
+		return m, m.bulkStepCmd()
+
+	case bulkItemDoneMsg:
+		return m.handleBulkItemDone(msg)
+
+	case cancelBulkMsg:
+		if m.bulk != nil && !m.bulk.canceled {
+			m.bulk.canceled = true
+			m.log.Loading("Canceling after the current schedule...")
+		}
+
+	case bulkFinishedMsg:
+		return m.handleBulkFinished(msg)
+
 	case saveConfigMsg:
 		return m.handleSaveConfig(msg)
 
 	case deleteConfigMsg:
 		return m.handleDeleteConfig(msg)
 
+	case collapsedGroupsChangedMsg:
+		if err := m.configService.SetCollapsedGroups(msg.groups); err != nil {
+			m.reloadChangedConfigs(err)
+			m.log.Error(err.Error())
+			return m, ClearStatusAfter(10 * time.Second)
+		}
+
 	case selectConfigMsg:
 		return m.handleSelectConfig(msg)
 
+	case loadBrowseGroupsMsg:
+		return m.handleLoadBrowseGroups(msg)
+
+	case loadBrowseProjectsMsg:
+		return m.handleLoadBrowseProjects(msg)
+
+	case browseGroupsLoadedMsg:
+		m.browser.SetGroups(msg.groups, msg.search, msg.page, msg.nextPage)
+		m.log.Clear()
+
+	case browseProjectsLoadedMsg:
+		m.browser.SetProjects(msg.projects, msg.groupID, msg.search, msg.page, msg.nextPage)
+		m.log.Clear()
+
+	case browseFailedMsg:
+		m.browser.LoadFailed()
+		m.log.Error(msg.err.Error())
+		return m, ClearStatusAfter(10 * time.Second)
+
+	case addProjectsMsg:
+		return m.handleAddProjects(msg)
+
+	case refreshDashboardMsg:
+		cmd := m.loadDashboardCmd()
+		return m, cmd
+
+	case dashboardProjectLoadedMsg:
+		m.dashboard.SetProjectSchedules(msg)
+		if !m.dashboard.IsLoading() && msg.generation == m.dashboard.generation {
+			m.log.Success("Dashboard updated")
+			cmds = append(cmds, ClearStatusAfter(3*time.Second))
+		}
+
+	case projectsAddedMsg:
+		m.configs = msg.configs
+		m.configList.SetItems(m.configs)
+		m.screen = ScreenConfigList
+		message := fmt.Sprintf("Added %d project(s)", len(msg.added))
+		if msg.skipped > 0 {
+			message += fmt.Sprintf(", %d already configured", msg.skipped)
+		}
+		m.log.Success(message)
+		cmds = append(cmds, ClearStatusAfter(10*time.Second))
+		return m, tea.Batch(append(cmds, m.checkTokenHealthCmds(msg.added)...)...)
+
+	case startDeviceLoginMsg:
+		return m.handleStartDeviceLogin(msg)
+
+	case deviceLoginStartedMsg:
+		if m.screen != ScreenNewConfig && m.screen != ScreenEditConfig {
+			return m, nil
+		}
+		m.configForm.SetDeviceAuthorization(msg.auth)
+		m.log.Info("Waiting for login approval...")
+		return m, pollDeviceLoginAfter(pollDeviceLoginMsg{
+			baseURL:       msg.baseURL,
+			applicationID: msg.applicationID,
+			deviceCode:    msg.auth.DeviceCode,
+			interval:      time.Duration(msg.auth.Interval) * time.Second,
+			deadline:      time.Now().Add(time.Duration(msg.auth.ExpiresIn) * time.Second),
+		})
+
+	case pollDeviceLoginMsg:
+		return m.handlePollDeviceLogin(msg)
+
+	case deviceLoginPendingMsg:
+		return m, pollDeviceLoginAfter(msg.next)
+
+	case deviceLoginFailedMsg:
+		m.configForm.CancelLogin()
+		m.log.Error(msg.err.Error())
+		return m, ClearStatusAfter(10 * time.Second)
+
+	case deviceLoginCompletedMsg:
+		m.configForm.SetOAuthCredentials(msg.creds)
+		m.log.Success("Logged in! Save the configuration to keep it.")
+		return m, ClearStatusAfter(10 * time.Second)
+
 	case quickRunPipelineMsg:
 		return m.handleQuickRunPipeline(msg)
 
+	case rerunPipelineMsg:
+		m.log.Loading(fmt.Sprintf("Loading variables of pipeline #%d...", msg.pipeline.ID))
+		return m, m.loadPipelineVariablesCmd(msg.pipeline)
+
+	case pipelineVariablesLoadedMsg:
+		m.log.Clear()
+		if m.screen == ScreenQuickRun {
+			cmd := m.quickRun.ShowRerunForm(msg.pipeline, msg.variables)
+			return m, cmd
+		}
+		return m, nil
+
+	case validateRefMsg:
+		m.log.Loading("Checking " + msg.ref + "...")
+		return m, m.resolveRefCmd(msg)
+
+	case refResolvedMsg:
+		if msg.err != nil {
+			m.log.Warning(msg.err.Error())
+			return m, ClearStatusAfter(10 * time.Second)
+		}
+		if msg.forSchedule {
+			if msg.ref.Commit {
+				// A schedule on the branch would run whatever it points to later
+				m.log.Warning(describeResolvedRef(msg.ref) + ", schedules run on a branch or tag, pick it instead")
+				return m, ClearStatusAfter(10 * time.Second)
+			}
+			m.log.Success(describeResolvedRef(msg.ref))
+			return m, tea.Batch(m.setScheduleRef(msg.ref.Name), ClearStatusAfter(5*time.Second))
+		}
+		m.log.Success(describeResolvedRef(msg.ref))
+		cmd := m.quickRun.SetRef(msg.ref.Name)
+		return m, tea.Batch(cmd, ClearStatusAfter(5*time.Second))
+
+	case loadRefsMsg:
+		return m, m.loadRefsCmd(msg)
+
+	case refPickedMsg:
+		if msg.forSchedule {
+			return m, m.setScheduleRef(msg.ref)
+		}
+		cmd := m.quickRun.SetRef(msg.ref)
+		return m, cmd
+
+	case loadRunFieldsMsg:
+		return m, m.loadRunFieldsCmd(msg.ref)
+
+	case runFieldsLoadedMsg:
+		m.quickRun.SetRunFields(msg.ref, msg.fields)
+		if msg.err != nil {
+			// The form still works with variables typed as KEY=value
+			m.log.Warning(fmt.Sprintf("Could not read %s: %v", services.CIConfigPath, msg.err))
+			return m, ClearStatusAfter(10 * time.Second)
+		}
+		return m, nil
+
 	case pipelineCreatedMsg:
-		m.log.Success("Pipeline started!")
+		m.log.Success(fmt.Sprintf("Pipeline #%d started!", msg.pipeline.ID))
 		// Refresh pipelines list
 		return m, tea.Batch(
 			ClearStatusAfter(5*time.Second),
-			m.loadPipelinesCmd(),
+			m.loadPipelinesCmd(1),
+			m.startWatch(msg.pipeline),
 		)
 
+	case toggleWatchMsg:
+		m.watchPipelines = !m.watchPipelines
+		if !m.watchPipelines {
+			m.watch = nil
+		}
+		if err := m.configService.SetWatchPipelines(m.watchPipelines); err != nil {
+			m.reloadChangedConfigs(err)
+			m.log.Error(fmt.Sprintf("Failed to save setting: %v", err))
+		} else if m.watchPipelines {
+			m.log.Success("Pipelines started from glcron are watched until they finish")
+		} else {
+			m.log.Success("Pipelines are no longer watched")
+		}
+		return m, ClearStatusAfter(5 * time.Second)
+
+	case pipelineWatchTickMsg:
+		if m.watch != nil && msg.pipelineID == m.watch.pipeline.ID {
+			return m, m.watchPipelineCmd()
+		}
+		return m, nil
+
+	case pipelineWatchLoadedMsg:
+		if m.watch == nil || msg.pipelineID != m.watch.pipeline.ID {
+			return m, nil
+		}
+		// Errors are usually transient, polling goes on
+		if msg.err == nil {
+			m.watch.update(msg.pipeline, msg.jobs)
+			if !msg.pipeline.IsRunning() {
+				watch := m.watch
+				m.watch = nil
+				switch msg.pipeline.Status {
+				case "success":
+					m.log.Success(watch.Summary())
+				case "failed":
+					m.log.Error(watch.Summary())
+				default:
+					m.log.Warning(watch.Summary())
+				}
+				return m, tea.Batch(watch.notifyCmd(m.output), ClearStatusAfter(30*time.Second))
+			}
+		}
+		tick := pipelineWatchTickMsg{pipelineID: msg.pipelineID}
+		return m, tea.Tick(WatchInterval, func(t time.Time) tea.Msg {
+			return tick
+		})
+
 	case pipelinesLoadedMsg:
-		m.quickRun.SetPipelines(msg.pipelines)
+		m.quickRun.SetPipelines(msg.pipelines, msg.filter, msg.page, msg.nextPage)
+		if msg.page > 1 {
+			// Loading older pages doesn't change when to refresh
+			m.log.Clear()
+			return m, nil
+		}
 		m.log.Success("Updated")
 		// Always schedule next refresh when on QuickRun screen
 		if m.screen == ScreenQuickRun {
-			cmds = append(cmds, tea.Tick(PipelineRefreshInterval, func(t time.Time) tea.Msg {
-				return refreshPipelinesMsg{}
-			}))
+			cmds = append(cmds, m.scheduleQuickRunRefresh())
 		}
 		cmds = append(cmds, ClearStatusAfter(3*time.Second))
 
+	case pipelinesUpdatedMsg:
+		if msg.err != nil {
+			// Keep refreshing, the next attempt may work
+			m.log.Error(msg.err.Error())
+			cmds = append(cmds, ClearStatusAfter(10*time.Second))
+		}
+		m.quickRun.MergePipelines(msg.pipelines, msg.removed, msg.filter)
+		if m.screen == ScreenQuickRun {
+			cmds = append(cmds, m.scheduleQuickRunRefresh())
+		}
+
 	case refreshPipelinesMsg:
 		if m.screen == ScreenQuickRun {
 			m.log.Loading("Refreshing...")
-			return m, m.loadPipelinesCmd()
+			return m, m.loadPipelinesCmd(1)
 		}
 
-	case pipelineTickMsg:
+	case loadMorePipelinesMsg:
 		if m.screen == ScreenQuickRun {
-			return m, m.loadPipelinesCmd()
+			m.log.Loading("Loading older pipelines...")
+			return m, m.loadPipelinesCmd(msg.page)
+		}
+
+	case pipelinesFailedMsg:
+		m.quickRun.LoadFailed()
+		m.log.Error(msg.err.Error())
+		return m, ClearStatusAfter(10 * time.Second)
+
+	case pipelineTickMsg:
+		if m.screen == ScreenQuickRun && msg.seq == m.quickRun.RefreshSeq() {
+			return m, m.refreshPipelinesCmd()
+		}
+
+	case pipelineDetailLoadedMsg:
+		m.pipeline.SetJobs(msg.pipeline, msg.jobs, msg.bridges)
+		m.log.Success("Updated")
+		cmds = append(cmds, ClearStatusAfter(3*time.Second))
+		// Follow running pipelines
+		if m.screen == ScreenPipelineDetail && m.pipeline.IsRunning() {
+			pipelineID := msg.pipeline.ID
+			cmds = append(cmds, tea.Tick(m.refreshInterval(), func(t time.Time) tea.Msg {
+				return pipelineDetailTickMsg{pipelineID: pipelineID}
+			}))
+		}
+
+	case refreshPipelineDetailMsg:
+		if m.screen == ScreenPipelineDetail {
+			m.log.Loading("Refreshing...")
+			return m, m.loadPipelineDetailCmd(m.pipeline.PipelineID())
+		}
+
+	case pipelineDetailTickMsg:
+		if m.screen == ScreenPipelineDetail && msg.pipelineID == m.pipeline.PipelineID() {
+			return m, m.loadPipelineDetailCmd(msg.pipelineID)
+		}
+
+	case scheduleHistoryLoadedMsg:
+		m.history.SetPipelines(msg.scheduleID, msg.pipelines)
+		m.log.Success("Updated")
+		cmds = append(cmds, ClearStatusAfter(3*time.Second))
+
+	case refreshScheduleHistoryMsg:
+		if m.screen == ScreenScheduleHistory {
+			m.log.Loading("Refreshing...")
+			return m, m.loadScheduleHistoryCmd(m.history.ScheduleID())
+		}
+
+	case pipelineActionMsg:
+		return m.handlePipelineAction(msg)
+
+	case pipelineActionDoneMsg:
+		m.log.Success(msg.message)
+		cmds = append(cmds, ClearStatusAfter(5*time.Second))
+		switch m.screen {
+		case ScreenQuickRun:
+			// Keeps the older pages loaded
+			cmds = append(cmds, m.refreshPipelinesCmd())
+		case ScreenPipelineDetail:
+			if msg.pipelineID == m.pipeline.PipelineID() {
+				cmds = append(cmds, m.loadPipelineDetailCmd(msg.pipelineID))
+			}
+		}
+
+	case loadJobTraceMsg:
+		offset := m.jobLog.Offset()
+		if msg.full {
+			offset = 0
+			m.log.Loading("Loading log...")
+		}
+		return m, m.loadJobTraceCmd(m.jobLog.JobID(), offset, m.jobLog.PollSeq())
+
+	case jobTraceLoadedMsg:
+		if msg.jobID != m.jobLog.JobID() {
+			return m, nil
+		}
+		m.jobLog.SetJobStatus(msg.job)
+		m.jobLog.AppendTrace(msg.data, msg.offset, msg.partial)
+		if msg.offset == 0 {
+			m.log.Clear()
 		}
+		// Keep polling while following the log of a running job
+		if m.screen == ScreenJobLog && m.jobLog.IsFollowing() && msg.pollSeq == m.jobLog.PollSeq() {
+			tick := jobTraceTickMsg{jobID: msg.jobID, pollSeq: msg.pollSeq}
+			cmds = append(cmds, tea.Tick(JobLogFollowInterval, func(t time.Time) tea.Msg {
+				return tick
+			}))
+		}
+
+	case jobTraceTickMsg:
+		if m.screen == ScreenJobLog && msg.jobID == m.jobLog.JobID() &&
+			msg.pollSeq == m.jobLog.PollSeq() && m.jobLog.IsFollowing() {
+			return m, m.loadJobTraceCmd(msg.jobID, m.jobLog.Offset(), msg.pollSeq)
+		}
+
+	case loadArtifactsArchiveMsg:
+		m.log.Loading("Downloading artifacts...")
+		return m, m.loadArtifactsArchiveCmd(m.artifacts.JobID())
+
+	case artifactsArchiveLoadedMsg:
+		m.artifacts.SetArchive(msg.jobID, msg.archive, msg.files, msg.err)
+		if msg.err != nil {
+			m.log.Error(msg.err.Error())
+			return m, ClearStatusAfter(5 * time.Second)
+		}
+		m.log.Clear()
+		return m, nil
+
+	case saveArtifactMsg:
+		var archive *services.ArtifactArchive
+		if msg.jobID == m.artifacts.JobID() {
+			archive = m.artifacts.Archive()
+		}
+		m.log.Loading("Saving " + msg.dest + "...")
+		return m, m.saveArtifactCmd(msg, archive)
 	}
 
 	switch m.screen {
@@ -308,6 +832,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.quickRun, cmd = m.quickRun.Update(msg)
 		cmds = append(cmds, cmd)
+
+	case ScreenBrowseProjects:
+		var cmd tea.Cmd
+		m.browser, cmd = m.browser.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ScreenDashboard:
+		var cmd tea.Cmd
+		m.dashboard, cmd = m.dashboard.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ScreenPipelineDetail:
+		var cmd tea.Cmd
+		m.pipeline, cmd = m.pipeline.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ScreenJobLog:
+		var cmd tea.Cmd
+		m.jobLog, cmd = m.jobLog.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ScreenScheduleHistory:
+		var cmd tea.Cmd
+		m.history, cmd = m.history.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ScreenArtifacts:
+		var cmd tea.Cmd
+		m.artifacts, cmd = m.artifacts.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -346,6 +900,18 @@ func (m Model) renderGrid() string {
 			content = m.configForm.View()
 		case ScreenQuickRun:
 			content = m.quickRun.View()
+		case ScreenBrowseProjects:
+			content = m.browser.View()
+		case ScreenDashboard:
+			content = m.dashboard.View()
+		case ScreenPipelineDetail:
+			content = m.pipeline.View()
+		case ScreenJobLog:
+			content = m.jobLog.View()
+		case ScreenScheduleHistory:
+			content = m.history.View()
+		case ScreenArtifacts:
+			content = m.artifacts.View()
 		}
 	}
 
@@ -401,10 +967,16 @@ func (m Model) renderHeader() string {
 		left += " - " + green.Render(m.configs[m.currentConfigIdx].Name)
 	}
 
-	// Use global LogPanel for status on right
+	// Use global LogPanel for status on right, after the progress of a watched pipeline
 	right := ""
+	if m.watch != nil {
+		right = m.watch.View() + " "
+		if m.log != nil && m.log.IsVisible() {
+			right += "│ "
+		}
+	}
 	if m.log != nil && m.log.IsVisible() {
-		right = m.log.RenderWithIcon() + " "
+		right += m.log.RenderWithIcon() + " "
 	}
 
 	// Calculate the visible widths (without ANSI codes)
@@ -437,34 +1009,186 @@ func (m Model) handleNavigation(msg navigateMsg) (tea.Model, tea.Cmd) {
 	case ScreenEditSchedule:
 		m.screen = ScreenEditSchedule
 		m.scheduleForm.SetCurrentUser(m.currentUser)
-		m.scheduleForm.SetSchedule(msg.schedule, m.branches, false)
+		m.scheduleForm.SetSchedule(msg.schedule, false)
 
 	case ScreenNewSchedule:
 		m.screen = ScreenNewSchedule
 		m.scheduleForm.SetCurrentUser(m.currentUser)
-		m.scheduleForm.SetSchedule(msg.schedule, m.branches, true)
+		m.scheduleForm.SetSchedule(msg.schedule, true)
 
 	case ScreenEditConfig:
 		m.screen = ScreenEditConfig
 		m.configForm.SetConfig(msg.config, msg.configIndex, false)
+		m.configForm.SetSharedWith(countInstanceUsers(m.configs, msg.config.Instance) - 1)
 
 	case ScreenNewConfig:
 		m.screen = ScreenNewConfig
 		m.configForm.SetConfig(nil, -1, true)
 
 	case ScreenQuickRun:
+		// Coming back from a pipeline or job log keeps the selection
+		if m.screen != ScreenPipelineDetail && m.screen != ScreenJobLog {
+			m.quickRun.Reset()
+		}
 		m.screen = ScreenQuickRun
-		m.quickRun.SetBranches(m.branches)
-		m.quickRun.Reset()
 		// Show loading on first open (using global log panel)
 		m.log.Loading("Loading pipelines...")
 		// Load pipelines
-		return m, m.loadPipelinesCmd()
+		return m, m.loadPipelinesCmd(1)
+
+	case ScreenBrowseProjects:
+		m.screen = ScreenBrowseProjects
+		// Start from the instance and token of the config under the cursor
+		baseURL, token := "", ""
+		if msg.config != nil {
+			baseURL, _ = services.BaseURLFromProjectURL(msg.config.ProjectURL)
+			if !msg.config.UsesOAuth() {
+				token = msg.config.Token
+			}
+		}
+		m.browser.Reset(baseURL, token, m.configs)
+
+	case ScreenPipelineDetail:
+		returnScreen := m.screen
+		m.screen = ScreenPipelineDetail
+		if msg.pipeline == nil {
+			// Back from a job log, refresh the pipeline shown
+			return m, m.loadPipelineDetailCmd(m.pipeline.PipelineID())
+		}
+		m.pipeline.SetPipeline(*msg.pipeline, returnScreen)
+		m.log.Loading("Loading jobs...")
+		return m, m.loadPipelineDetailCmd(msg.pipeline.ID)
+
+	case ScreenJobLog:
+		if msg.job == nil {
+			// Back from the artifacts, resume following the log shown
+			m.screen = ScreenJobLog
+			if m.jobLog.IsFollowing() {
+				return m, m.loadJobTraceCmd(m.jobLog.JobID(), m.jobLog.Offset(), m.jobLog.PollSeq())
+			}
+			return m, nil
+		}
+		m.jobLog.SetJob(*msg.job, m.screen)
+		m.screen = ScreenJobLog
+		m.log.Loading("Loading log...")
+		return m, m.loadJobTraceCmd(msg.job.ID, 0, m.jobLog.PollSeq())
+
+	case ScreenDashboard:
+		m.screen = ScreenDashboard
+		cmd := m.loadDashboardCmd()
+		return m, cmd
+
+	case ScreenScheduleHistory:
+		m.screen = ScreenScheduleHistory
+		if msg.schedule == nil {
+			// Back from a pipeline, refresh the schedule shown
+			return m, m.loadScheduleHistoryCmd(m.history.ScheduleID())
+		}
+		m.history.SetSchedule(*msg.schedule)
+		m.log.Loading("Loading history...")
+		return m, m.loadScheduleHistoryCmd(msg.schedule.ID)
+
+	case ScreenArtifacts:
+		m.artifacts.SetJob(*msg.job, m.screen)
+		m.screen = ScreenArtifacts
+		m.log.Clear()
 	}
 
 	return m, nil
 }
 
+// loadDashboardCmd reloads the dashboard, fetching the schedules of every config concurrently
+func (m *Model) loadDashboardCmd() tea.Cmd {
+	if len(m.configs) == 0 {
+		m.dashboard.Reset(nil)
+		return nil
+	}
+
+	m.log.Loading(fmt.Sprintf("Loading schedules of %d projects...", len(m.configs)))
+	generation := m.dashboard.Reset(m.configs)
+	gitlabService := m.gitlabService
+
+	cmds := make([]tea.Cmd, 0, len(m.configs))
+	for i, config := range m.configs {
+		i, config := i, config
+		cmds = append(cmds, func() tea.Msg {
+			schedules, err := gitlabService.GetSchedulesFor(&config)
+			return dashboardProjectLoadedMsg{generation: generation, configIdx: i, schedules: schedules, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// countInstanceUsers returns how many configs use the named instance
+func countInstanceUsers(configs []models.Config, instance string) int {
+	if instance == "" {
+		return 0
+	}
+	count := 0
+	for _, config := range configs {
+		if config.Instance == instance {
+			count++
+		}
+	}
+	return count
+}
+
+func (m Model) handleLoadBrowseGroups(msg loadBrowseGroupsMsg) (tea.Model, tea.Cmd) {
+	m.log.Loading("Loading groups...")
+	gitlabService := m.gitlabService
+
+	return m, func() tea.Msg {
+		groups, nextPage, err := gitlabService.ListGroups(msg.baseURL, msg.token, msg.search, msg.page)
+		if err != nil {
+			return browseFailedMsg{err}
+		}
+		return browseGroupsLoadedMsg{groups: groups, search: msg.search, page: msg.page, nextPage: nextPage}
+	}
+}
+
+func (m Model) handleLoadBrowseProjects(msg loadBrowseProjectsMsg) (tea.Model, tea.Cmd) {
+	m.log.Loading("Loading projects...")
+	gitlabService := m.gitlabService
+
+	return m, func() tea.Msg {
+		projects, nextPage, err := gitlabService.ListProjects(msg.baseURL, msg.token, msg.groupID, msg.search, msg.page)
+		if err != nil {
+			return browseFailedMsg{err}
+		}
+		return browseProjectsLoadedMsg{projects: projects, groupID: msg.groupID, search: msg.search, page: msg.page, nextPage: nextPage}
+	}
+}
+
+func (m Model) handleAddProjects(msg addProjectsMsg) (tea.Model, tea.Cmd) {
+	m.log.Loading("Adding projects...")
+	configService := m.configService
+
+	existing := make(map[string]bool)
+	for _, config := range m.configs {
+		existing[strings.TrimSuffix(config.ProjectURL, "/")] = true
+	}
+
+	return m, func() tea.Msg {
+		var added []models.Config
+		skipped := 0
+		for _, config := range msg.configs {
+			if existing[strings.TrimSuffix(config.ProjectURL, "/")] {
+				skipped++
+				continue
+			}
+			added = append(added, config)
+		}
+
+		if len(added) > 0 {
+			if err := configService.AddConfigs(added); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		return projectsAddedMsg{configs: configService.GetConfigs(), added: added, skipped: skipped}
+	}
+}
+
 func (m Model) handleSelectConfig(msg selectConfigMsg) (tea.Model, tea.Cmd) {
 	if msg.index < 0 || msg.index >= len(m.configs) {
 		return m, nil
@@ -486,18 +1210,15 @@ func (m Model) handleSelectConfig(msg selectConfigMsg) (tea.Model, tea.Cmd) {
 			return errMsg{err}
 		}
 
-		branches, _ := gitlabService.GetBranches()
-		branchNames := make([]string, len(branches))
-		for i, b := range branches {
-			branchNames[i] = b.Name
-		}
+		// Remember when the project was opened for "recently used" ordering
+		now := time.Now()
+		config.LastOpenedAt = &now
 
 		// Get current user for ownership checks
 		currentUser, _ := gitlabService.GetCurrentUser()
 
 		return configSelectedMsg{
 			schedules:     schedules,
-			branches:      branchNames,
 			updatedConfig: &config, // Contains ProjectID from API
 			currentUser:   currentUser,
 		}
@@ -672,16 +1393,117 @@ func (m Model) handleRunSchedule(msg runScheduleMsg) (tea.Model, tea.Cmd) {
 
 	gitlabService := m.gitlabService
 
+	// The pipeline started is the one replacing the schedule's last pipeline
+	previousID := 0
+	for _, s := range m.schedules {
+		if s.ID == msg.id && s.LastPipeline != nil {
+			previousID = s.LastPipeline.ID
+		}
+	}
+
 	return m, func() tea.Msg {
 		if err := gitlabService.RunSchedule(msg.id); err != nil {
 			return errMsg{err}
 		}
 
 		schedules, _ := gitlabService.GetSchedules()
-		return schedulesSavedMsg{schedules: schedules, message: "Pipeline started!"}
+		return schedulesSavedMsg{schedules: schedules, message: "Pipeline started!", ranScheduleID: msg.id, previousPipelineID: previousID}
 	}
 }
 
+// setScheduleRef uses a picked ref in the schedule form, or for the bulk branch change of
+// the schedule list
+func (m *Model) setScheduleRef(ref string) tea.Cmd {
+	if m.screen == ScreenScheduleList {
+		return m.scheduleList.SetBulkRef(ref)
+	}
+	m.scheduleForm.SetRef(ref)
+	return nil
+}
+
+// bulkStepCmd applies the running bulk action to its next schedule
+func (m Model) bulkStepCmd() tea.Cmd {
+	index := m.bulk.next()
+	action := m.bulk.action
+	value := m.bulk.value
+	schedule := m.bulk.schedules[index]
+	m.log.Loading(fmt.Sprintf("%s %d/%d: %s... (Esc to cancel)", action.progress(), index+1, len(m.bulk.schedules), scheduleLabel(schedule)))
+
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		done := bulkItemDoneMsg{index: index}
+		switch action {
+		case bulkEnable, bulkDisable:
+			active := action == bulkEnable
+			_, done.err = gitlabService.UpdateSchedule(schedule.ID, &models.ScheduleUpdateRequest{Active: &active})
+		case bulkRun:
+			done.err = gitlabService.RunSchedule(schedule.ID)
+		case bulkTakeOwnership:
+			_, done.err = gitlabService.TakeOwnership(schedule.ID)
+		case bulkChangeRef:
+			_, done.err = gitlabService.UpdateSchedule(schedule.ID, &models.ScheduleUpdateRequest{Ref: &value})
+		case bulkChangeTimezone:
+			_, done.err = gitlabService.UpdateSchedule(schedule.ID, &models.ScheduleUpdateRequest{CronTimezone: &value})
+		case bulkDelete:
+			done.err = gitlabService.DeleteSchedule(schedule.ID)
+		}
+		return done
+	}
+}
+
+func (m Model) handleBulkItemDone(msg bulkItemDoneMsg) (tea.Model, tea.Cmd) {
+	if m.bulk == nil || msg.index != m.bulk.next() {
+		return m, nil
+	}
+
+	schedule := m.bulk.schedules[msg.index]
+	m.bulk.results = append(m.bulk.results, bulkResult{schedule: schedule, err: msg.err})
+	m.scheduleList.SetBulkResult(schedule.ID, msg.err)
+
+	if m.bulk.next() < len(m.bulk.schedules) && !m.bulk.canceled {
+		return m, m.bulkStepCmd()
+	}
+	m.bulk.skipRest()
+
+	// Reload the list, the schedules changed one by one
+	m.log.Loading("Refreshing...")
+	gitlabService := m.gitlabService
+	return m, func() tea.Msg {
+		schedules, err := gitlabService.GetSchedules()
+		return bulkFinishedMsg{schedules: schedules, err: err}
+	}
+}
+
+func (m Model) handleBulkFinished(msg bulkFinishedMsg) (tea.Model, tea.Cmd) {
+	bulk := m.bulk
+	m.bulk = nil
+	if bulk == nil {
+		return m, nil
+	}
+
+	var refChecksCmd tea.Cmd
+	if msg.err == nil {
+		m.schedules = msg.schedules
+		m.filteredSchedules = msg.schedules
+		m.scheduleList.SetItems(m.filteredSchedules)
+		refChecksCmd = m.loadRefChecksCmd(msg.schedules)
+		bulk.setPipelines(msg.schedules)
+	}
+	m.scheduleList.FinishBulk(bulk)
+
+	_, failed, _ := bulk.counts()
+	switch {
+	case msg.err != nil:
+		m.log.Error(fmt.Sprintf("%s, but refreshing the list failed: %v", bulk.Summary(), msg.err))
+	case failed > 0 || bulk.canceled:
+		m.log.Warning(bulk.Summary())
+	default:
+		m.log.Success(bulk.Summary())
+	}
+	return m, tea.Batch(ClearStatusAfter(10*time.Second), refChecksCmd)
+}
+
 func (m Model) handleRefreshSchedules() (tea.Model, tea.Cmd) {
 	m.log.Loading("Refreshing...")
 
@@ -712,12 +1534,12 @@ func (m Model) handleTakeOwnership(msg takeOwnershipMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
-
 func (m Model) handleSaveConfig(msg saveConfigMsg) (tea.Model, tea.Cmd) {
 	m.log.Loading("Validating...")
 
 	gitlabService := m.gitlabService
 	configService := m.configService
+	expiryWarningDays := m.tokenExpiryWarningDays
 
 	// Get existing config to preserve ProjectID/BaseURL if updating
 	var existingConfig *models.Config
@@ -731,6 +1553,29 @@ func (m Model) handleSaveConfig(msg saveConfigMsg) (tea.Model, tea.Cmd) {
 			Name:       msg.name,
 			ProjectURL: msg.url,
 			Token:      msg.token,
+			AuthType:   msg.authType,
+			OAuth:      msg.oauth,
+			Group:      msg.group,
+			Tags:       msg.tags,
+
+			RefreshInterval: msg.refresh,
+		}
+		if config.UsesOAuth() {
+			config.Token = ""
+		}
+
+		// Projects on a known instance can leave the credentials empty to share the instance's
+		if config.Token == "" && config.OAuth == nil {
+			if instance, ok := configService.InstanceForURL(config.ProjectURL); ok {
+				config.Token = instance.Token
+				config.AuthType = instance.AuthType
+				config.OAuth = instance.OAuth
+			}
+		}
+
+		if existingConfig != nil {
+			config.Instance = existingConfig.Instance
+			config.LastOpenedAt = existingConfig.LastOpenedAt
 		}
 
 		// Preserve ProjectID and BaseURL if URL hasn't changed
@@ -743,9 +1588,14 @@ func (m Model) handleSaveConfig(msg saveConfigMsg) (tea.Model, tea.Cmd) {
 			return errMsg{err}
 		}
 
+		// Token problems are reported but don't block saving
+		health, _ := gitlabService.CheckTokenHealth(&config, expiryWarningDays)
+
 		var err error
+		index := msg.index
 		if msg.isNew {
 			err = configService.AddConfig(config)
+			index = len(configService.GetConfigs()) - 1
 		} else {
 			err = configService.UpdateConfig(msg.index, config)
 		}
@@ -754,11 +1604,103 @@ func (m Model) handleSaveConfig(msg saveConfigMsg) (tea.Model, tea.Cmd) {
 			return errMsg{err}
 		}
 
-		configFile, _ := configService.Load()
-		return configSavedMsg{configs: configFile.Configs, message: "Configuration saved!"}
+		_, _ = configService.Load()
+		configs := configService.GetConfigs()
+		healthKey, instance := "", ""
+		if index >= 0 && index < len(configs) {
+			// Saving assigns the instance the key depends on
+			healthKey = tokenHealthKey(configs[index])
+			instance = configs[index].Instance
+		}
+		return configSavedMsg{
+			configs:   configs,
+			message:   "Configuration saved!",
+			healthKey: healthKey,
+			health:    health,
+			instance:  instance,
+		}
+	}
+}
+
+// checkTokenHealthCmds returns one background health check per config
+func (m Model) checkTokenHealthCmds(configs []models.Config) []tea.Cmd {
+	gitlabService := m.gitlabService
+	expiryWarningDays := m.tokenExpiryWarningDays
+
+	cmds := make([]tea.Cmd, 0, len(configs))
+	for _, config := range configs {
+		config := config
+		cmds = append(cmds, func() tea.Msg {
+			health, err := gitlabService.CheckTokenHealth(&config, expiryWarningDays)
+			if err != nil {
+				health = &models.TokenHealth{Warnings: []string{"Health check failed: " + err.Error()}}
+			}
+			return tokenHealthLoadedMsg{healthKey: tokenHealthKey(config), health: health}
+		})
+	}
+	return cmds
+}
+
+// tokenHealthKey identifies the health of a config's token: it depends on the credentials,
+// shared by the configs of an instance, and on the project
+func tokenHealthKey(config models.Config) string {
+	return config.Instance + " " + config.ProjectURL
+}
+
+func (m Model) handleStartDeviceLogin(msg startDeviceLoginMsg) (tea.Model, tea.Cmd) {
+	baseURL, err := services.BaseURLFromProjectURL(msg.projectURL)
+	if err != nil {
+		m.log.Error("Enter the project URL first: " + err.Error())
+		return m, ClearStatusAfter(10 * time.Second)
+	}
+
+	m.log.Loading("Starting login...")
+	gitlabService := m.gitlabService
+
+	return m, func() tea.Msg {
+		auth, err := gitlabService.StartDeviceLogin(baseURL, msg.applicationID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return deviceLoginStartedMsg{baseURL: baseURL, applicationID: msg.applicationID, auth: auth}
+	}
+}
+
+func (m Model) handlePollDeviceLogin(msg pollDeviceLoginMsg) (tea.Model, tea.Cmd) {
+	// Stop polling if the user left the form or cancelled the login
+	if (m.screen != ScreenNewConfig && m.screen != ScreenEditConfig) || !m.configForm.LoginPending() {
+		return m, nil
+	}
+	if time.Now().After(msg.deadline) {
+		m.configForm.CancelLogin()
+		m.log.Error("Login code expired, start again")
+		return m, ClearStatusAfter(10 * time.Second)
+	}
+
+	gitlabService := m.gitlabService
+
+	return m, func() tea.Msg {
+		creds, err := gitlabService.PollDeviceLogin(msg.baseURL, msg.applicationID, msg.deviceCode)
+		switch {
+		case errors.Is(err, services.ErrAuthorizationPending):
+			return deviceLoginPendingMsg{next: msg}
+		case errors.Is(err, services.ErrSlowDown):
+			msg.interval += 5 * time.Second
+			return deviceLoginPendingMsg{next: msg}
+		case err != nil:
+			return deviceLoginFailedMsg{err: err}
+		}
+		return deviceLoginCompletedMsg{creds: creds}
 	}
 }
 
+// pollDeviceLoginAfter schedules the next device login poll
+func pollDeviceLoginAfter(msg pollDeviceLoginMsg) tea.Cmd {
+	return tea.Tick(msg.interval, func(t time.Time) tea.Msg {
+		return msg
+	})
+}
+
 func (m Model) handleDeleteConfig(msg deleteConfigMsg) (tea.Model, tea.Cmd) {
 	configService := m.configService
 
@@ -767,8 +1709,74 @@ func (m Model) handleDeleteConfig(msg deleteConfigMsg) (tea.Model, tea.Cmd) {
 			return errMsg{err}
 		}
 
-		configFile, _ := configService.Load()
-		return configSavedMsg{configs: configFile.Configs, message: "Configuration deleted!"}
+		_, _ = configService.Load()
+		return configSavedMsg{configs: configService.GetConfigs(), message: "Configuration deleted!"}
+	}
+}
+
+// loadPipelineVariablesCmd fetches the variables of a pipeline to run it again
+func (m Model) loadPipelineVariablesCmd(pipeline models.Pipeline) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		variables, err := gitlabService.GetPipelineVariables(pipeline.ID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return pipelineVariablesLoadedMsg{pipeline: pipeline, variables: variables}
+	}
+}
+
+// resolveRefCmd checks a typed branch, tag or commit SHA against the repository
+func (m Model) resolveRefCmd(msg validateRefMsg) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		ref, err := gitlabService.ResolveRef(msg.ref)
+		return refResolvedMsg{ref: ref, forSchedule: msg.forSchedule, err: err}
+	}
+}
+
+// loadRefsCmd fetches a page of the branches or tags matching the picker's search.
+// Tags are listed after the branches, their first page comes with the last branch page.
+func (m Model) loadRefsCmd(msg loadRefsMsg) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		loaded := refsLoadedMsg{query: msg.query, branchPage: msg.branchPage}
+		tagPage := msg.tagPage
+		if msg.branchPage > 0 {
+			branches, nextPage, err := gitlabService.ListBranches(msg.query, msg.branchPage)
+			if err != nil {
+				loaded.err = err
+				return loaded
+			}
+			loaded.branches = branches
+			loaded.nextBranchPage = nextPage
+			if nextPage == 0 {
+				tagPage = 1
+			}
+		}
+		if tagPage > 0 {
+			tags, nextPage, err := gitlabService.ListTags(msg.query, tagPage)
+			if err != nil {
+				loaded.err = err
+				return loaded
+			}
+			loaded.tags = tags
+			loaded.nextTagPage = nextPage
+		}
+		return loaded
+	}
+}
+
+// loadRunFieldsCmd fetches the inputs and variables declared in .gitlab-ci.yml at ref
+func (m Model) loadRunFieldsCmd(ref string) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		fields, err := gitlabService.GetRunFields(ref)
+		return runFieldsLoadedMsg{ref: ref, fields: fields, err: err}
 	}
 }
 
@@ -781,6 +1789,7 @@ func (m Model) handleQuickRunPipeline(msg quickRunPipelineMsg) (tea.Model, tea.C
 		req := &models.PipelineCreateRequest{
 			Ref:       msg.branch,
 			Variables: msg.variables,
+			Inputs:    msg.inputs,
 		}
 
 		pipeline, err := gitlabService.CreatePipeline(req)
@@ -792,79 +1801,384 @@ func (m Model) handleQuickRunPipeline(msg quickRunPipelineMsg) (tea.Model, tea.C
 	}
 }
 
-func (m Model) loadPipelinesCmd() tea.Cmd {
+func (m Model) handlePipelineAction(msg pipelineActionMsg) (tea.Model, tea.Cmd) {
+	m.log.Loading(msg.Label() + "...")
+
 	gitlabService := m.gitlabService
 
-	return func() tea.Msg {
-		pipelines, err := gitlabService.GetPipelines(QuickRunPipelinesListLimit)
+	return m, func() tea.Msg {
+		var err error
+		var message string
+		switch msg.action {
+		case actionCancelPipeline:
+			err = gitlabService.CancelPipeline(msg.pipelineID)
+			message = "Pipeline canceled!"
+		case actionRetryPipeline:
+			err = gitlabService.RetryPipeline(msg.pipelineID)
+			message = "Pipeline retried!"
+		case actionCancelJob:
+			err = gitlabService.CancelJob(msg.jobID)
+			message = "Job canceled!"
+		case actionRetryJob:
+			err = gitlabService.RetryJob(msg.jobID)
+			message = "Job retried!"
+		case actionPlayJob:
+			err = gitlabService.PlayJob(msg.jobID)
+			message = "Job started!"
+		}
 		if err != nil {
 			return errMsg{err}
 		}
 
+		return pipelineActionDoneMsg{pipelineID: msg.pipelineID, message: message}
+	}
+}
+
+// loadPipelinesCmd fetches a page of the Quick Run list with its filter
+func (m Model) loadPipelinesCmd(page int) tea.Cmd {
+	gitlabService := m.gitlabService
+	filter := m.quickRun.Filter()
+
+	return func() tea.Msg {
+		pipelines, nextPage, err := gitlabService.ListPipelines(filter, page, QuickRunPipelinesListLimit)
+		if err != nil {
+			return pipelinesFailedMsg{err}
+		}
+
 		// Load details for each pipeline
 		var pipelinesWithJobs []models.PipelineWithJobs
 		for _, p := range pipelines {
-			// Fetch full pipeline details to get user info
-			fullPipeline, err := gitlabService.GetPipeline(p.ID)
-			if err == nil && fullPipeline != nil {
-				p = *fullPipeline
+			pipeline, _ := loadPipelineWithJobs(gitlabService, p)
+			pipelinesWithJobs = append(pipelinesWithJobs, pipeline)
+		}
+
+		return pipelinesLoadedMsg{pipelines: pipelinesWithJobs, filter: filter, page: page, nextPage: nextPage}
+	}
+}
+
+// loadPipelineWithJobs fetches the details, jobs and stages of a pipeline of the list.
+// If the details can't be fetched, p is shown as it is and false is returned.
+func loadPipelineWithJobs(gitlabService services.GitLabServiceInterface, p models.Pipeline) (models.PipelineWithJobs, bool) {
+	// Fetch full pipeline details to get user info
+	fullPipeline, err := gitlabService.GetPipeline(p.ID)
+	if err == nil && fullPipeline != nil {
+		p = *fullPipeline
+	}
+
+	jobs, _ := gitlabService.GetPipelineJobs(p.ID)
+
+	// Aggregate stages from jobs
+	stageMap := make(map[string]string) // stage name -> status
+	stageOrder := []string{}
+	for _, job := range jobs {
+		if _, exists := stageMap[job.Stage]; !exists {
+			stageOrder = append(stageOrder, job.Stage)
+			stageMap[job.Stage] = job.Status
+		} else {
+			// Update status if this job has a "worse" status
+			currentStatus := stageMap[job.Stage]
+			if shouldUpdateStageStatus(currentStatus, job.Status) {
+				stageMap[job.Stage] = job.Status
 			}
+		}
+	}
+
+	// Reverse stage order (GitLab API returns jobs in reverse order)
+	for i, j := 0, len(stageOrder)-1; i < j; i, j = i+1, j-1 {
+		stageOrder[i], stageOrder[j] = stageOrder[j], stageOrder[i]
+	}
 
-			jobs, _ := gitlabService.GetPipelineJobs(p.ID)
-
-			// Aggregate stages from jobs
-			stageMap := make(map[string]string) // stage name -> status
-			stageOrder := []string{}
-			for _, job := range jobs {
-				if _, exists := stageMap[job.Stage]; !exists {
-					stageOrder = append(stageOrder, job.Stage)
-					stageMap[job.Stage] = job.Status
-				} else {
-					// Update status if this job has a "worse" status
-					currentStatus := stageMap[job.Stage]
-					if shouldUpdateStageStatus(currentStatus, job.Status) {
-						stageMap[job.Stage] = job.Status
-					}
+	var stages []models.StageInfo
+	for _, stageName := range stageOrder {
+		stages = append(stages, models.StageInfo{
+			Name:   stageName,
+			Status: stageMap[stageName],
+		})
+	}
+
+	// For triggered pipelines, try to get upstream project name
+	upstreamProjectName := ""
+	if isTriggerSource(p.Source) {
+		bridges, _ := gitlabService.GetPipelineBridges(p.ID)
+		for _, bridge := range bridges {
+			if bridge.UpstreamPipeline != nil && bridge.UpstreamPipeline.Project != nil {
+				upstreamProjectName = bridge.UpstreamPipeline.Project.PathWithNamespace
+				if upstreamProjectName == "" {
+					upstreamProjectName = bridge.UpstreamPipeline.Project.Name
 				}
+				break
 			}
+		}
+	}
 
-			// Reverse stage order (GitLab API returns jobs in reverse order)
-			for i, j := 0, len(stageOrder)-1; i < j; i, j = i+1, j-1 {
-				stageOrder[i], stageOrder[j] = stageOrder[j], stageOrder[i]
-			}
+	return models.PipelineWithJobs{
+		Pipeline:            p,
+		Jobs:                jobs,
+		Stages:              stages,
+		UpstreamProjectName: upstreamProjectName,
+	}, err == nil
+}
 
-			var stages []models.StageInfo
-			for _, stageName := range stageOrder {
-				stages = append(stages, models.StageInfo{
-					Name:   stageName,
-					Status: stageMap[stageName],
-				})
-			}
+// refreshPipelinesCmd fetches only the pipelines updated since the last refresh and the ones
+// still running, the others are left as they are
+func (m Model) refreshPipelinesCmd() tea.Cmd {
+	lastUpdate := m.quickRun.LastUpdate()
+	if lastUpdate == nil {
+		return m.loadPipelinesCmd(1)
+	}
 
-			// For triggered pipelines, try to get upstream project name
-			upstreamProjectName := ""
-			if isTriggerSource(p.Source) {
-				bridges, _ := gitlabService.GetPipelineBridges(p.ID)
-				for _, bridge := range bridges {
-					if bridge.UpstreamPipeline != nil && bridge.UpstreamPipeline.Project != nil {
-						upstreamProjectName = bridge.UpstreamPipeline.Project.PathWithNamespace
-						if upstreamProjectName == "" {
-							upstreamProjectName = bridge.UpstreamPipeline.Project.Name
-						}
-						break
-					}
-				}
+	gitlabService := m.gitlabService
+	filter := m.quickRun.Filter()
+	known := m.quickRun.PipelineUpdates()
+	running := m.quickRun.RunningPipelineIDs()
+
+	// Same filter, narrowed to what changed since the last refresh
+	query := filter
+	if since := *lastUpdate; query.UpdatedAfter == nil || query.UpdatedAfter.Before(since) {
+		query.UpdatedAfter = &since
+	}
+
+	return func() tea.Msg {
+		updated, _, err := gitlabService.ListPipelines(query, 1, QuickRunPipelinesListLimit)
+		if err != nil {
+			return pipelinesUpdatedMsg{filter: filter, err: err}
+		}
+
+		var changed []models.PipelineWithJobs
+		var removed []int
+		seen := make(map[int]bool)
+		for _, p := range updated {
+			seen[p.ID] = true
+			// updated_after includes the last update already listed
+			if at, ok := known[p.ID]; ok && p.UpdatedAt != nil && at.Equal(*p.UpdatedAt) && !p.IsRunning() {
+				continue
 			}
+			pipeline, _ := loadPipelineWithJobs(gitlabService, p)
+			changed = append(changed, pipeline)
+		}
+		// Jobs can progress without the pipeline being updated. These are not listed through
+		// the filter, e.g. a pipeline listed as running may have finished since.
+		for _, id := range running {
+			if seen[id] {
+				continue
+			}
+			pipeline, ok := loadPipelineWithJobs(gitlabService, models.Pipeline{ID: id})
+			switch {
+			case !ok:
+			case filter.Matches(pipeline.Pipeline):
+				changed = append(changed, pipeline)
+			default:
+				removed = append(removed, id)
+			}
+		}
+
+		return pipelinesUpdatedMsg{pipelines: changed, removed: removed, filter: filter}
+	}
+}
+
+// scheduleQuickRunRefresh schedules the next refresh of the Quick Run list, replacing the one scheduled before
+func (m *Model) scheduleQuickRunRefresh() tea.Cmd {
+	interval, seq := m.quickRun.NextRefresh(m.refreshInterval())
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return pipelineTickMsg{seq: seq}
+	})
+}
+
+// refreshInterval returns how often running pipelines of the current config are refreshed
+func (m Model) refreshInterval() time.Duration {
+	if m.currentConfigIdx >= 0 && m.currentConfigIdx < len(m.configs) && m.configs[m.currentConfigIdx].RefreshInterval > 0 {
+		return time.Duration(m.configs[m.currentConfigIdx].RefreshInterval) * time.Second
+	}
+	return PipelineRefreshInterval
+}
 
-			pipelinesWithJobs = append(pipelinesWithJobs, models.PipelineWithJobs{
-				Pipeline:            p,
-				Jobs:                jobs,
-				Stages:              stages,
-				UpstreamProjectName: upstreamProjectName,
-			})
+// loadPipelineDetailCmd fetches a pipeline with its jobs and downstream bridges
+func (m Model) loadPipelineDetailCmd(pipelineID int) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		pipeline, err := gitlabService.GetPipeline(pipelineID)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		jobs, err := gitlabService.GetPipelineJobs(pipelineID)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		// Pipelines without trigger jobs are common, a failure here shouldn't hide the jobs
+		bridges, _ := gitlabService.GetPipelineBridges(pipelineID)
+
+		return pipelineDetailLoadedMsg{pipeline: pipeline, jobs: jobs, bridges: bridges}
+	}
+}
+
+// startWatch follows a pipeline just started, if watching is enabled. It replaces the pipeline watched so far.
+func (m *Model) startWatch(pipeline *models.Pipeline) tea.Cmd {
+	if !m.watchPipelines || pipeline == nil || m.currentConfigIdx < 0 || m.currentConfigIdx >= len(m.configs) {
+		return nil
+	}
+	m.watch = &pipelineWatch{config: m.configs[m.currentConfigIdx], pipeline: *pipeline}
+	return m.watchPipelineCmd()
+}
+
+// findSchedulePipelineCmd waits for the pipeline of a schedule just run to be created, so it
+// can be watched. Nothing is looked up when watching is disabled.
+func (m Model) findSchedulePipelineCmd(scheduleID, previousID int) tea.Cmd {
+	if !m.watchPipelines || scheduleID == 0 || m.currentConfigIdx < 0 || m.currentConfigIdx >= len(m.configs) {
+		return nil
+	}
+	gitlabService := m.gitlabService
+	config := m.configs[m.currentConfigIdx]
+	configIdx := m.currentConfigIdx
+
+	return func() tea.Msg {
+		pipeline, err := gitlabService.WaitForSchedulePipelineFor(&config, scheduleID, previousID)
+		if err != nil || pipeline == nil {
+			return statusMsg{text: "Pipeline started, but it did not show up in time to be watched", msgType: string(LogTypeWarning)}
 		}
+		return schedulePipelineFoundMsg{configIdx: configIdx, pipeline: pipeline}
+	}
+}
+
+// watchPipelineCmd fetches the watched pipeline and its jobs from its own project
+func (m Model) watchPipelineCmd() tea.Cmd {
+	gitlabService := m.gitlabService
+	config := m.watch.config
+	pipelineID := m.watch.pipeline.ID
+
+	return func() tea.Msg {
+		pipeline, jobs, err := gitlabService.GetPipelineFor(&config, pipelineID)
+		return pipelineWatchLoadedMsg{pipelineID: pipelineID, pipeline: pipeline, jobs: jobs, err: err}
+	}
+}
+
+// loadReliabilityCmd computes the statistics of each schedule in the background, a few
+// schedules at a time. Loads started earlier stop once this one starts.
+func (m *Model) loadReliabilityCmd(schedules []models.Schedule) tea.Cmd {
+	m.reliabilitySeq++
 
-		return pipelinesLoadedMsg{pipelines: pipelinesWithJobs}
+	queues := make([][]int, ReliabilityLoads)
+	for i, s := range schedules {
+		queues[i%ReliabilityLoads] = append(queues[i%ReliabilityLoads], s.ID)
+	}
+
+	var cmds []tea.Cmd
+	for _, queue := range queues {
+		if len(queue) > 0 {
+			cmds = append(cmds, m.loadScheduleReliabilityCmd(m.currentConfigIdx, m.reliabilitySeq, queue))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadScheduleReliabilityCmd computes the statistics of the first schedule of the queue,
+// the rest is loaded once it is done
+func (m Model) loadScheduleReliabilityCmd(configIdx, seq int, queue []int) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		reliability, err := gitlabService.GetScheduleReliability(queue[0], services.ReliabilityRuns)
+		if err != nil {
+			// The columns stay empty, the schedule list itself loaded fine
+			reliability = nil
+		}
+		return reliabilityLoadedMsg{configIdx: configIdx, seq: seq, reliability: reliability, queue: queue[1:]}
+	}
+}
+
+// loadRefChecksCmd checks the branch or tag of each schedule in the background
+func (m Model) loadRefChecksCmd(schedules []models.Schedule) tea.Cmd {
+	gitlabService := m.gitlabService
+	configIdx := m.currentConfigIdx
+
+	return func() tea.Msg {
+		checks, err := gitlabService.CheckScheduleRefs(schedules)
+		if err != nil {
+			// No warning is shown, the schedule list itself loaded fine
+			return nil
+		}
+		return refChecksLoadedMsg{configIdx: configIdx, checks: checks}
+	}
+}
+
+// loadScheduleHistoryCmd fetches the latest pipelines of a schedule, with their durations
+func (m Model) loadScheduleHistoryCmd(scheduleID int) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		pipelines, err := gitlabService.GetSchedulePipelines(scheduleID, ScheduleHistoryLimit)
+		if err != nil {
+			return errMsg{err}
+		}
+		// Durations stay empty when they can't be fetched, the history is still shown
+		_ = gitlabService.FillPipelineDurations(pipelines)
+		return scheduleHistoryLoadedMsg{scheduleID: scheduleID, pipelines: pipelines}
+	}
+}
+
+// loadJobTraceCmd fetches the log of a job from offset on, along with its current status
+func (m Model) loadJobTraceCmd(jobID int, offset int64, pollSeq int) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		data, partial, err := gitlabService.GetJobTrace(jobID, offset)
+		if err != nil {
+			return errMsg{err}
+		}
+		job, _ := gitlabService.GetJob(jobID)
+		return jobTraceLoadedMsg{jobID: jobID, job: job, data: data, offset: offset, partial: partial, pollSeq: pollSeq}
+	}
+}
+
+// loadArtifactsArchiveCmd downloads the artifacts archive of a job and lists its files
+func (m Model) loadArtifactsArchiveCmd(jobID int) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		archive, err := gitlabService.GetArtifactsArchive(jobID)
+		if err != nil {
+			return artifactsArchiveLoadedMsg{jobID: jobID, err: err}
+		}
+		files, err := archive.Files()
+		return artifactsArchiveLoadedMsg{jobID: jobID, archive: archive, files: files, err: err}
+	}
+}
+
+// saveArtifactCmd saves the artifacts archive of a job, or a file of it, to disk.
+// An archive already downloaded for browsing is reused instead of fetched again.
+func (m Model) saveArtifactCmd(msg saveArtifactMsg, archive *services.ArtifactArchive) tea.Cmd {
+	gitlabService := m.gitlabService
+
+	return func() tea.Msg {
+		file, dest, err := services.CreateDownloadFile(msg.dest)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		switch {
+		case archive != nil && msg.file == "":
+			_, err = archive.WriteTo(file)
+			if err != nil {
+				err = fmt.Errorf("failed to write %s: %v", dest, err)
+			}
+		case archive != nil:
+			err = archive.Extract(msg.file, file)
+		case msg.file == "":
+			err = gitlabService.DownloadArtifacts(msg.jobID, file)
+		default:
+			err = gitlabService.DownloadArtifactFile(msg.jobID, msg.file, file)
+		}
+
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write %s: %v", dest, closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(dest)
+			return errMsg{err}
+		}
+		return statusMsg{text: "Saved to " + dest, msgType: "success"}
 	}
 }
 
@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"os"
+	"sync"
+)
+
+// Output is the terminal the program renders to. Writes are serialized, so the bell rung
+// from a command lands between two frames instead of inside an escape sequence.
+type Output struct {
+	*os.File
+	mu sync.Mutex
+}
+
+// NewOutput wraps the terminal, pass it to tea.WithOutput and StartupOptions.Output
+func NewOutput(file *os.File) *Output {
+	return &Output{File: file}
+}
+
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.File.Write(p)
+}
+
+func (o *Output) WriteString(s string) (int, error) {
+	return o.Write([]byte(s))
+}
+
+// Bell rings the terminal bell
+func (o *Output) Bell() {
+	_, _ = o.Write([]byte("\a"))
+}
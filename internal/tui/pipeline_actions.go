@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pipelineAction is an action on a running or finished pipeline or job
+type pipelineAction int
+
+const (
+	actionCancelPipeline pipelineAction = iota
+	actionRetryPipeline
+	actionCancelJob
+	actionRetryJob
+	actionPlayJob
+)
+
+// newPipelineAction checks that the action applies to a pipeline in the given status.
+// It returns the command to run, or a warning explaining why the action is not possible.
+func newPipelineAction(action pipelineAction, pipeline *models.Pipeline) (pipelineActionMsg, tea.Cmd) {
+	msg := pipelineActionMsg{action: action, pipelineID: pipeline.ID, name: fmt.Sprintf("pipeline #%d", pipeline.ID)}
+
+	switch action {
+	case actionCancelPipeline:
+		if !canCancel(pipeline.Status) {
+			return msg, actionWarning("Pipeline is not running")
+		}
+	case actionRetryPipeline:
+		if pipeline.Status != "failed" && pipeline.Status != "canceled" {
+			return msg, actionWarning("Only failed or canceled pipelines can be retried")
+		}
+	}
+	return msg, nil
+}
+
+// newJobAction checks that the action applies to the job, see newPipelineAction
+func newJobAction(action pipelineAction, pipelineID int, job *models.PipelineJob) (pipelineActionMsg, tea.Cmd) {
+	msg := pipelineActionMsg{action: action, pipelineID: pipelineID, jobID: job.ID, name: fmt.Sprintf("job \"%s\"", job.Name)}
+
+	switch action {
+	case actionCancelJob:
+		if !canCancel(job.Status) {
+			return msg, actionWarning("Job is not running")
+		}
+	case actionRetryJob:
+		if job.Status != "success" && job.Status != "failed" && job.Status != "canceled" {
+			return msg, actionWarning("Only finished jobs can be retried")
+		}
+	case actionPlayJob:
+		if job.Status != "manual" {
+			return msg, actionWarning("Only manual jobs can be started")
+		}
+	}
+	return msg, nil
+}
+
+func canCancel(status string) bool {
+	return isJobRunning(status) || status == "scheduled"
+}
+
+func actionWarning(text string) tea.Cmd {
+	return func() tea.Msg {
+		return statusMsg{text: text, msgType: string(LogTypeWarning)}
+	}
+}
+
+// NeedsConfirmation returns true for actions that stop running work
+func (a pipelineActionMsg) NeedsConfirmation() bool {
+	return a.action == actionCancelPipeline || a.action == actionCancelJob
+}
+
+// ConfirmPopup returns the confirmation dialog of the action
+func (a pipelineActionMsg) ConfirmPopup() *ConfirmPopup {
+	return NewConfirmPopup(
+		"Cancel",
+		fmt.Sprintf("Cancel %s?", a.name),
+		"",
+		"Running jobs are stopped.",
+	).WithButtons("Yes, Cancel", "No").WithWidth(50)
+}
+
+// Label describes the action in status messages
+func (a pipelineActionMsg) Label() string {
+	switch a.action {
+	case actionCancelPipeline, actionCancelJob:
+		return "Canceling " + a.name
+	case actionRetryPipeline, actionRetryJob:
+		return "Retrying " + a.name
+	default:
+		return "Starting " + a.name
+	}
+}
+
+// Cmd returns the command that sends the action
+func (a pipelineActionMsg) Cmd() tea.Cmd {
+	return func() tea.Msg {
+		return a
+	}
+}
+
+// handleConfirmKey handles a key while a confirmation popup is shown.
+// It returns whether the popup was closed and whether the action was confirmed.
+func handleConfirmKey(popup *ConfirmPopup, key string) (closed, confirmed bool) {
+	switch key {
+	case "left", "h":
+		popup.SelectYes()
+	case "right", "l":
+		popup.SelectNo()
+	case "y", "Y":
+		return true, true
+	case "n", "N", "esc":
+		return true, false
+	case "enter":
+		return true, popup.IsYesSelected()
+	}
+	return false, false
+}
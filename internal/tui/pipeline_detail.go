@@ -0,0 +1,581 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column widths for the pipeline detail job list
+const (
+	ColJobStatus   = 12
+	ColJobName     = 30
+	ColJobDuration = 10
+	ColJobStarted  = 16
+)
+
+// PipelineDetailModel lists the jobs and downstream pipelines of one pipeline, grouped by stage
+type PipelineDetailModel struct {
+	width  int
+	height int
+
+	pipeline     models.Pipeline
+	jobs         []models.PipelineJob
+	bridges      []models.PipelineBridge
+	loaded       bool
+	returnScreen Screen // Screen the pipeline was opened from
+
+	rows         []pipelineDetailRow
+	cursor       int // Index into rows, never on a stage header
+	scrollOffset int
+
+	// Cancel confirmation
+	actionPopup   *ConfirmPopup
+	pendingAction pipelineActionMsg
+}
+
+// pipelineDetailRow is a line of the job list: a stage header, a job or a bridge
+type pipelineDetailRow struct {
+	stage  string
+	status string // Aggregated status, headers only
+	job    *models.PipelineJob
+	bridge *models.PipelineBridge
+}
+
+func NewPipelineDetailModel() PipelineDetailModel {
+	return PipelineDetailModel{}
+}
+
+func (m *PipelineDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetPipeline shows a pipeline while its jobs are loading
+func (m *PipelineDetailModel) SetPipeline(pipeline models.Pipeline, returnScreen Screen) {
+	m.pipeline = pipeline
+	m.returnScreen = returnScreen
+	m.jobs = nil
+	m.bridges = nil
+	m.loaded = false
+	m.rows = nil
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.actionPopup = nil
+}
+
+// SetJobs sets the jobs and bridges of the pipeline, keeping the cursor on the same job
+func (m *PipelineDetailModel) SetJobs(pipeline *models.Pipeline, jobs []models.PipelineJob, bridges []models.PipelineBridge) {
+	if pipeline.ID != m.pipeline.ID {
+		return
+	}
+
+	selectedID := 0
+	if m.cursor < len(m.rows) {
+		selectedID = m.rows[m.cursor].id()
+	}
+
+	m.pipeline = *pipeline
+	m.jobs = jobs
+	m.bridges = bridges
+	m.loaded = true
+	m.buildRows()
+
+	m.cursor = m.firstItem()
+	for i, row := range m.rows {
+		if selectedID != 0 && row.id() == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// PipelineID returns the ID of the pipeline shown
+func (m *PipelineDetailModel) PipelineID() int {
+	return m.pipeline.ID
+}
+
+// IsRunning returns true while the pipeline can still change
+func (m *PipelineDetailModel) IsRunning() bool {
+	return m.pipeline.IsRunning()
+}
+
+func (r pipelineDetailRow) id() int {
+	switch {
+	case r.job != nil:
+		return r.job.ID
+	case r.bridge != nil:
+		return r.bridge.ID
+	default:
+		return 0
+	}
+}
+
+func (r pipelineDetailRow) isHeader() bool {
+	return r.job == nil && r.bridge == nil
+}
+
+// buildRows groups jobs and bridges by stage, ordering stages by their first created job
+func (m *PipelineDetailModel) buildRows() {
+	type stageGroup struct {
+		name    string
+		firstID int
+		status  string
+		rows    []pipelineDetailRow
+	}
+	groups := make(map[string]*stageGroup)
+	var order []*stageGroup
+
+	add := func(stage, status string, id int, row pipelineDetailRow) {
+		g, ok := groups[stage]
+		if !ok {
+			g = &stageGroup{name: stage, firstID: id, status: status}
+			groups[stage] = g
+			order = append(order, g)
+		}
+		if id < g.firstID {
+			g.firstID = id
+		}
+		if shouldUpdateStageStatus(g.status, status) {
+			g.status = status
+		}
+		g.rows = append(g.rows, row)
+	}
+
+	for i := range m.jobs {
+		job := &m.jobs[i]
+		add(job.Stage, job.Status, job.ID, pipelineDetailRow{stage: job.Stage, job: job})
+	}
+	for i := range m.bridges {
+		bridge := &m.bridges[i]
+		add(bridge.Stage, bridge.Status, bridge.ID, pipelineDetailRow{stage: bridge.Stage, bridge: bridge})
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].firstID < order[j].firstID
+	})
+
+	m.rows = nil
+	for _, g := range order {
+		sort.SliceStable(g.rows, func(i, j int) bool {
+			return g.rows[i].id() < g.rows[j].id()
+		})
+		m.rows = append(m.rows, pipelineDetailRow{stage: g.name, status: g.status})
+		m.rows = append(m.rows, g.rows...)
+	}
+}
+
+// firstItem returns the index of the first job or bridge row
+func (m *PipelineDetailModel) firstItem() int {
+	for i, row := range m.rows {
+		if !row.isHeader() {
+			return i
+		}
+	}
+	return 0
+}
+
+// move steps the cursor over jobs and bridges, skipping stage headers
+func (m *PipelineDetailModel) move(delta int) {
+	for i := m.cursor + delta; i >= 0 && i < len(m.rows); i += delta {
+		if !m.rows[i].isHeader() {
+			m.cursor = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// getVisibleRows returns how many job rows can be shown
+func (m *PipelineDetailModel) getVisibleRows() int {
+	// Height minus pipeline summary, empty line, header row
+	return m.height - 3
+}
+
+// adjustScroll ensures the cursor is visible, along with the header of the first stage
+func (m *PipelineDetailModel) adjustScroll() {
+	visibleRows := m.getVisibleRows()
+	if visibleRows <= 0 {
+		visibleRows = 5
+	}
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+	if m.cursor == m.firstItem() {
+		m.scrollOffset = 0
+	}
+}
+
+func (m PipelineDetailModel) Update(msg tea.Msg) (PipelineDetailModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.actionPopup != nil {
+			closed, confirmed := handleConfirmKey(m.actionPopup, msg.String())
+			if closed {
+				m.actionPopup = nil
+			}
+			if confirmed {
+				return m, m.pendingAction.Cmd()
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc":
+			return m, Navigate(m.returnScreen)
+		case "up", "k":
+			m.move(-1)
+		case "down", "j":
+			m.move(1)
+		case "enter", "l":
+			if m.cursor < len(m.rows) && m.rows[m.cursor].job != nil {
+				job := *m.rows[m.cursor].job
+				return m, NavigateToJobLog(&job)
+			}
+		case "a":
+			if m.cursor < len(m.rows) && m.rows[m.cursor].job != nil {
+				job := *m.rows[m.cursor].job
+				return m, NavigateToArtifacts(&job)
+			}
+		case "x":
+			return m.startJobAction(actionCancelJob)
+		case "t":
+			return m.startJobAction(actionRetryJob)
+		case "p":
+			return m.startJobAction(actionPlayJob)
+		case "X":
+			return m.startAction(newPipelineAction(actionCancelPipeline, &m.pipeline))
+		case "T":
+			return m.startAction(newPipelineAction(actionRetryPipeline, &m.pipeline))
+		case "u":
+			return m, func() tea.Msg {
+				return refreshPipelineDetailMsg{}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// startJobAction runs an action on the selected job
+func (m PipelineDetailModel) startJobAction(action pipelineAction) (PipelineDetailModel, tea.Cmd) {
+	if !m.loaded || m.cursor >= len(m.rows) || m.rows[m.cursor].job == nil {
+		return m, nil
+	}
+	return m.startAction(newJobAction(action, m.pipeline.ID, m.rows[m.cursor].job))
+}
+
+// startAction runs a checked action, asking first if it cancels anything
+func (m PipelineDetailModel) startAction(msg pipelineActionMsg, warning tea.Cmd) (PipelineDetailModel, tea.Cmd) {
+	if warning != nil {
+		return m, warning
+	}
+	if !m.loaded {
+		return m, nil
+	}
+	if msg.NeedsConfirmation() {
+		m.pendingAction = msg
+		m.actionPopup = msg.ConfirmPopup()
+		return m, nil
+	}
+	return m, msg.Cmd()
+}
+
+func (m PipelineDetailModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	if m.actionPopup != nil {
+		return m.actionPopup.View(m.width, m.height)
+	}
+
+	// Split into left (2/3) and right (1/3) columns
+	leftWidth := (m.width * 2) / 3
+	rightWidth := m.width - leftWidth - 1
+
+	leftLines := m.renderLeftColumn(leftWidth)
+	rightLines := m.renderDetailsPanel(rightWidth)
+
+	var result []string
+	maxLines := maxInt(len(leftLines), len(rightLines))
+
+	for i := 0; i < maxLines; i++ {
+		left := ""
+		if i < len(leftLines) {
+			left = leftLines[i]
+		}
+		left = padToWidth(left, leftWidth)
+
+		right := ""
+		if i < len(rightLines) {
+			right = rightLines[i]
+		}
+		right = padToWidth(right, rightWidth)
+
+		result = append(result, left+"│"+right)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (m PipelineDetailModel) renderLeftColumn(width int) []string {
+	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+	indent := "   "
+
+	var lines []string
+
+	// Pipeline summary
+	p := m.pipeline
+	icon, style := getStatusIconAndStyle(p.Status)
+	pipelineID := fmt.Sprintf("#%d", p.ID)
+	if p.WebURL != "" {
+		pipelineID = fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", p.WebURL, pipelineID)
+	}
+	summary := indent + TitleStyle.Render("Pipeline ") + pipelineID + "  " +
+		style.Render(icon+" "+p.Status) + "  " +
+		BlueStyle.Render(p.Ref) + "  " +
+		GrayStyle.Render(getTriggerInfo(p.Source, p.User))
+	lines = append(lines, summary)
+	lines = append(lines, "")
+
+	headerRow := indent +
+		padRight("Status", ColJobStatus) +
+		padRight("Job", ColJobName) +
+		padRight("Duration", ColJobDuration) +
+		padRight("Started", ColJobStarted) +
+		"Runner"
+	lines = append(lines, headerStyle.Render(headerRow))
+
+	visibleRows := m.getVisibleRows()
+	needsScroll := NeedsScrollbar(len(m.rows), visibleRows)
+	scrollbar := RenderScrollbar(ScrollbarConfig{
+		TotalItems:   len(m.rows),
+		VisibleItems: visibleRows,
+		ScrollOffset: m.scrollOffset,
+		Height:       visibleRows,
+	})
+
+	for rowIdx := 0; rowIdx < visibleRows; rowIdx++ {
+		i := m.scrollOffset + rowIdx
+
+		scrollChar := " "
+		if needsScroll && rowIdx < len(scrollbar) {
+			scrollChar = scrollbar[rowIdx]
+		}
+
+		if i >= len(m.rows) {
+			line := ""
+			if i == 0 {
+				if m.loaded {
+					line = indent + GrayStyle.Render("This pipeline has no jobs.")
+				} else {
+					line = indent + GrayStyle.Render("Loading jobs...")
+				}
+			}
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+			continue
+		}
+
+		row := m.rows[i]
+		if row.isHeader() {
+			stageIcon, stageStyle := getStatusIconAndStyle(row.status)
+			line := " " + stageStyle.Render(stageIcon) + " " + headerStyle.Bold(true).Render(row.stage)
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+			continue
+		}
+
+		status, name, duration, started, runner := m.rowColumns(row)
+		statusIcon, statusStyle := getStatusIconAndStyle(status)
+
+		colStatus := padRight(statusIcon+" "+status, ColJobStatus)
+		colName := padRight(truncateStr(name, ColJobName-2), ColJobName)
+		colDuration := padRight(duration, ColJobDuration)
+		colStarted := padRight(started, ColJobStarted)
+		runnerWidth := width - 1 - len(indent) - ColJobStatus - ColJobName - ColJobDuration - ColJobStarted
+		colRunner := truncateStr(runner, maxInt(runnerWidth, 4))
+
+		if i == m.cursor {
+			plainRow := indent + colStatus + colName + colDuration + colStarted + colRunner
+			lines = append(lines, padToWidth(SelectedStyle.Render(padToWidth(plainRow, width-1)), width-1)+scrollChar)
+		} else {
+			line := indent + statusStyle.Render(colStatus) + colName + colDuration + colStarted + GrayStyle.Render(colRunner)
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+		}
+	}
+
+	return lines
+}
+
+// rowColumns returns the column values of a job or bridge row
+func (m PipelineDetailModel) rowColumns(row pipelineDetailRow) (status, name, duration, started, runner string) {
+	if row.job != nil {
+		job := row.job
+		runner = "-"
+		if job.Runner != nil {
+			runner = runnerName(job.Runner)
+		}
+		return job.Status, job.Name, formatJobDuration(job.Duration, job.StartedAt), formatStarted(job.StartedAt), runner
+	}
+
+	bridge := row.bridge
+	runner = "↳ downstream"
+	if bridge.DownstreamPipeline != nil {
+		runner = fmt.Sprintf("↳ #%d", bridge.DownstreamPipeline.ID)
+		if bridge.DownstreamPipeline.Project != nil {
+			runner += " " + bridge.DownstreamPipeline.Project.PathWithNamespace
+		}
+	}
+	return bridge.Status, bridge.Name, formatJobDuration(bridge.Duration, bridge.StartedAt), formatStarted(bridge.StartedAt), runner
+}
+
+func (m PipelineDetailModel) renderDetailsPanel(width int) []string {
+	label := YellowStyle.Bold(true)
+	blue := BlueStyle
+	gray := GrayStyle
+
+	var lines []string
+
+	boxTitle := " Details "
+	titleWidth := lipgloss.Width(boxTitle)
+	borderLen := width - titleWidth - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+boxTitle+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	if m.cursor < len(m.rows) && !m.rows[m.cursor].isHeader() {
+		row := m.rows[m.cursor]
+		status, name, duration, _, _ := m.rowColumns(row)
+		icon, style := getStatusIconAndStyle(status)
+
+		content = append(content, TitleStyle.Render(truncateStr(name, width-6)))
+		content = append(content, "  "+style.Render(icon+" "+status))
+		content = append(content, "")
+
+		content = append(content, label.Render("Job"))
+		content = append(content, "  "+blue.Render("Stage:")+" "+row.stage)
+		var startedAt *time.Time
+		var webURL string
+		if row.job != nil {
+			startedAt, webURL = row.job.StartedAt, row.job.WebURL
+			content = append(content, "  "+blue.Render("ID:")+" "+fmt.Sprintf("%d", row.job.ID))
+		} else {
+			startedAt, webURL = row.bridge.StartedAt, row.bridge.WebURL
+			content = append(content, "  "+blue.Render("Type:")+" trigger")
+		}
+		if startedAt != nil {
+			content = append(content, "  "+blue.Render("Started:")+" "+startedAt.Local().Format("2006-01-02 15:04:05"))
+		} else {
+			content = append(content, "  "+blue.Render("Started:")+" "+gray.Render("not started"))
+		}
+		content = append(content, "  "+blue.Render("Duration:")+" "+duration)
+		content = append(content, "")
+
+		if row.job != nil {
+			content = append(content, label.Render("Runner"))
+			if row.job.Runner == nil {
+				content = append(content, "  "+gray.Render("(No runner yet)"))
+			} else {
+				kind := "project runner"
+				if row.job.Runner.IsShared {
+					kind = "shared runner"
+				}
+				content = append(content, "  "+runnerName(row.job.Runner))
+				content = append(content, "  "+gray.Render(fmt.Sprintf("#%d, %s", row.job.Runner.ID, kind)))
+			}
+			content = append(content, "")
+		}
+
+		if row.bridge != nil {
+			content = append(content, label.Render("Downstream Pipeline"))
+			downstream := row.bridge.DownstreamPipeline
+			if downstream == nil {
+				content = append(content, "  "+gray.Render("(Not created yet)"))
+			} else {
+				dIcon, dStyle := getStatusIconAndStyle(downstream.Status)
+				linkText := fmt.Sprintf("Pipeline #%d", downstream.ID)
+				if downstream.WebURL != "" {
+					linkText = fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", downstream.WebURL, blue.Render(linkText))
+				}
+				content = append(content, "  "+linkText+" "+dStyle.Render(dIcon+" "+downstream.Status))
+				if downstream.Project != nil {
+					content = append(content, "  "+blue.Render("Project:")+" "+downstream.Project.PathWithNamespace)
+				}
+				if downstream.Ref != "" {
+					content = append(content, "  "+blue.Render("Ref:")+" "+downstream.Ref)
+				}
+			}
+			content = append(content, "")
+		}
+
+		if webURL != "" {
+			content = append(content, label.Render("Link"))
+			hyperlink := fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", webURL, blue.Render(truncateURL(webURL, width-8)))
+			content = append(content, "  "+hyperlink)
+		}
+	} else {
+		jobCount, bridgeCount := len(m.jobs), len(m.bridges)
+		content = append(content, label.Render("Pipeline"))
+		content = append(content, fmt.Sprintf("  %d job(s), %d trigger(s)", jobCount, bridgeCount))
+	}
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+// runnerName returns the display name of a runner
+func runnerName(runner *models.Runner) string {
+	if runner.Description != "" {
+		return runner.Description
+	}
+	return fmt.Sprintf("#%d", runner.ID)
+}
+
+// formatJobDuration formats a job duration in seconds, measuring running jobs up to now
+func formatJobDuration(seconds float64, startedAt *time.Time) string {
+	if seconds <= 0 && startedAt != nil {
+		seconds = time.Since(*startedAt).Seconds()
+	}
+	if seconds <= 0 {
+		return "-"
+	}
+	d := time.Duration(seconds) * time.Second
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatStarted formats when a job started
+func formatStarted(startedAt *time.Time) string {
+	if startedAt == nil {
+		return "-"
+	}
+	return formatTimeAgo(*startedAt)
+}
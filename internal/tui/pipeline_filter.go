@@ -0,0 +1,319 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pipelineFilterDateLayout is how the date range of the filter is typed
+const pipelineFilterDateLayout = "2006-01-02"
+
+// Values offered by the status and source selectors, "" matches everything
+var (
+	pipelineStatuses = []string{"", "running", "pending", "success", "failed", "canceled", "skipped", "manual", "scheduled", "created"}
+	pipelineSources  = []string{"", "schedule", "web", "push", "merge_request_event", "api", "trigger", "pipeline", "parent_pipeline", "external", "chat", "webide"}
+)
+
+type PipelineFilterField int
+
+const (
+	PipelineFilterFieldRef PipelineFilterField = iota
+	PipelineFilterFieldStatus
+	PipelineFilterFieldSource
+	PipelineFilterFieldUser
+	PipelineFilterFieldFrom
+	PipelineFilterFieldTo
+	PipelineFilterFieldApply
+	PipelineFilterFieldClear
+)
+
+// PipelineFilterForm edits the filter of the Quick Run pipeline list
+type PipelineFilterForm struct {
+	focusedField PipelineFilterField
+
+	refInput  textinput.Model
+	userInput textinput.Model
+	fromInput textinput.Model
+	toInput   textinput.Model
+	statusIdx int
+	sourceIdx int
+}
+
+func NewPipelineFilterForm() PipelineFilterForm {
+	newInput := func(placeholder string, width int) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = 100
+		ti.Width = width
+		ti.Cursor.Style = CursorStyle
+		return ti
+	}
+
+	return PipelineFilterForm{
+		refInput:  newInput("Any branch or tag", 40),
+		userInput: newInput("Any username", 30),
+		fromInput: newInput("YYYY-MM-DD", 12),
+		toInput:   newInput("YYYY-MM-DD", 12),
+	}
+}
+
+// SetFilter fills the form with the filter in use
+func (f *PipelineFilterForm) SetFilter(filter models.PipelineFilter) {
+	f.refInput.SetValue(filter.Ref)
+	f.userInput.SetValue(filter.Username)
+	f.fromInput.SetValue("")
+	if filter.UpdatedAfter != nil {
+		f.fromInput.SetValue(filter.UpdatedAfter.Format(pipelineFilterDateLayout))
+	}
+	f.toInput.SetValue("")
+	if filter.UpdatedBefore != nil {
+		// The form shows the last day included
+		f.toInput.SetValue(filter.UpdatedBefore.AddDate(0, 0, -1).Format(pipelineFilterDateLayout))
+	}
+	f.statusIdx = indexOf(pipelineStatuses, filter.Status)
+	f.sourceIdx = indexOf(pipelineSources, filter.Source)
+
+	f.blurAll()
+	f.focusedField = PipelineFilterFieldRef
+	f.refInput.Focus()
+}
+
+// Filter returns the filter typed in the form, the date range covering whole local days
+func (f *PipelineFilterForm) Filter() (models.PipelineFilter, error) {
+	filter := models.PipelineFilter{
+		Ref:      strings.TrimSpace(f.refInput.Value()),
+		Status:   pipelineStatuses[f.statusIdx],
+		Source:   pipelineSources[f.sourceIdx],
+		Username: strings.TrimPrefix(strings.TrimSpace(f.userInput.Value()), "@"),
+	}
+
+	if value := strings.TrimSpace(f.fromInput.Value()); value != "" {
+		from, err := time.ParseInLocation(pipelineFilterDateLayout, value, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("invalid Updated from date %q, use YYYY-MM-DD", value)
+		}
+		filter.UpdatedAfter = &from
+	}
+	if value := strings.TrimSpace(f.toInput.Value()); value != "" {
+		to, err := time.ParseInLocation(pipelineFilterDateLayout, value, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("invalid Updated to date %q, use YYYY-MM-DD", value)
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.UpdatedBefore = &to
+	}
+	if filter.UpdatedAfter != nil && filter.UpdatedBefore != nil && !filter.UpdatedAfter.Before(*filter.UpdatedBefore) {
+		return filter, fmt.Errorf("the Updated from date is after the Updated to date")
+	}
+
+	return filter, nil
+}
+
+func (f *PipelineFilterForm) blurAll() {
+	f.refInput.Blur()
+	f.userInput.Blur()
+	f.fromInput.Blur()
+	f.toInput.Blur()
+}
+
+// focusedInput returns the text input of the focused field, nil for selectors and buttons
+func (f *PipelineFilterForm) focusedInput() *textinput.Model {
+	switch f.focusedField {
+	case PipelineFilterFieldRef:
+		return &f.refInput
+	case PipelineFilterFieldUser:
+		return &f.userInput
+	case PipelineFilterFieldFrom:
+		return &f.fromInput
+	case PipelineFilterFieldTo:
+		return &f.toInput
+	}
+	return nil
+}
+
+func (f *PipelineFilterForm) moveFocus(delta int) {
+	f.blurAll()
+	count := int(PipelineFilterFieldClear) + 1
+	f.focusedField = PipelineFilterField((int(f.focusedField) + delta + count) % count)
+	if input := f.focusedInput(); input != nil {
+		input.Focus()
+	}
+}
+
+// cycle changes the value of the focused selector
+func (f *PipelineFilterForm) cycle(delta int) {
+	switch f.focusedField {
+	case PipelineFilterFieldStatus:
+		f.statusIdx = (f.statusIdx + delta + len(pipelineStatuses)) % len(pipelineStatuses)
+	case PipelineFilterFieldSource:
+		f.sourceIdx = (f.sourceIdx + delta + len(pipelineSources)) % len(pipelineSources)
+	}
+}
+
+// apply emits the filter typed, or warns about an invalid date
+func (f PipelineFilterForm) apply() tea.Cmd {
+	filter, err := f.Filter()
+	if err != nil {
+		return func() tea.Msg {
+			return statusMsg{text: err.Error(), msgType: string(LogTypeWarning)}
+		}
+	}
+	return func() tea.Msg {
+		return pipelineFilterAppliedMsg{filter: filter}
+	}
+}
+
+func (f PipelineFilterForm) Update(msg tea.KeyMsg) (PipelineFilterForm, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return f, func() tea.Msg {
+			return pipelineFilterClosedMsg{}
+		}
+	case "tab", "down":
+		f.moveFocus(1)
+		return f, nil
+	case "shift+tab", "up":
+		f.moveFocus(-1)
+		return f, nil
+	case "ctrl+s":
+		return f, f.apply()
+	case "enter":
+		switch f.focusedField {
+		case PipelineFilterFieldClear:
+			return f, func() tea.Msg {
+				return pipelineFilterAppliedMsg{}
+			}
+		case PipelineFilterFieldApply:
+			return f, f.apply()
+		default:
+			f.moveFocus(1)
+			return f, nil
+		}
+	case "left":
+		switch f.focusedField {
+		case PipelineFilterFieldStatus, PipelineFilterFieldSource:
+			f.cycle(-1)
+			return f, nil
+		case PipelineFilterFieldClear:
+			f.focusedField = PipelineFilterFieldApply
+			return f, nil
+		}
+	case "right":
+		switch f.focusedField {
+		case PipelineFilterFieldStatus, PipelineFilterFieldSource:
+			f.cycle(1)
+			return f, nil
+		case PipelineFilterFieldApply:
+			f.focusedField = PipelineFilterFieldClear
+			return f, nil
+		}
+	}
+
+	if input := f.focusedInput(); input != nil {
+		var cmd tea.Cmd
+		*input, cmd = input.Update(msg)
+		return f, cmd
+	}
+	return f, nil
+}
+
+// View renders the form as a panel of the given size, closed by a separator
+func (f PipelineFilterForm) View(width, height int) []string {
+	label := LabelStyle
+	selected := SelectedStyle
+
+	var lines []string
+
+	title := " 🔍 Filter Pipelines "
+	borderLen := width - lipgloss.Width(title) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+title+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	labelWidth := 18
+
+	selector := func(field PipelineFilterField, name string, values []string, idx int) string {
+		value := values[idx]
+		if value == "" {
+			value = "any"
+		}
+		value = "◂ " + value + " ▸"
+		if f.focusedField == field {
+			value = selected.Render(" " + value + " ")
+		}
+		return label.Render(padRight("  "+name, labelWidth)) + " " + value
+	}
+
+	content = append(content, label.Render(padRight("  Branch / tag", labelWidth))+" "+f.refInput.View())
+	content = append(content, selector(PipelineFilterFieldStatus, "Status", pipelineStatuses, f.statusIdx))
+	content = append(content, selector(PipelineFilterFieldSource, "Source", pipelineSources, f.sourceIdx))
+	content = append(content, label.Render(padRight("  User", labelWidth))+" "+f.userInput.View())
+	content = append(content, label.Render(padRight("  Updated from", labelWidth))+" "+f.fromInput.View())
+	content = append(content, label.Render(padRight("  Updated to", labelWidth))+" "+f.toInput.View())
+	content = append(content, "")
+
+	applyBtn := " ✔ Apply "
+	clearBtn := " ✖ Clear Filters "
+	switch f.focusedField {
+	case PipelineFilterFieldApply:
+		applyBtn = selected.Render(applyBtn)
+	case PipelineFilterFieldClear:
+		clearBtn = selected.Render(clearBtn)
+	}
+	content = append(content, "  "+applyBtn+"   "+clearBtn+"   "+GrayStyle.Render("Esc to close"))
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "├"+strings.Repeat("─", width-2)+"┤")
+
+	return lines
+}
+
+// describePipelineFilter summarizes a filter for the list title, "" if it is empty
+func describePipelineFilter(filter models.PipelineFilter) string {
+	var parts []string
+	if filter.Ref != "" {
+		parts = append(parts, "ref:"+filter.Ref)
+	}
+	if filter.Status != "" {
+		parts = append(parts, "status:"+filter.Status)
+	}
+	if filter.Source != "" {
+		parts = append(parts, "source:"+filter.Source)
+	}
+	if filter.Username != "" {
+		parts = append(parts, "user:@"+filter.Username)
+	}
+	if filter.UpdatedAfter != nil {
+		parts = append(parts, "updated-from:"+filter.UpdatedAfter.Format(pipelineFilterDateLayout))
+	}
+	if filter.UpdatedBefore != nil {
+		parts = append(parts, "updated-to:"+filter.UpdatedBefore.AddDate(0, 0, -1).Format(pipelineFilterDateLayout))
+	}
+	return strings.Join(parts, " ")
+}
+
+// indexOf returns the position of value in values, 0 if it is missing
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return 0
+}
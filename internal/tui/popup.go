@@ -128,8 +128,11 @@ func (p *ConfirmPopup) Render() []string {
 
 // View renders the popup centered on a full screen
 func (p *ConfirmPopup) View(screenWidth, screenHeight int) string {
-	dialogLines := p.Render()
-	dialogWidth := p.Width
+	return centerOnScreen(p.Render(), p.Width, screenWidth, screenHeight)
+}
+
+// centerOnScreen places dialog lines in the middle of an otherwise empty screen
+func centerOnScreen(dialogLines []string, dialogWidth, screenWidth, screenHeight int) string {
 	dialogHeight := len(dialogLines)
 
 	// Calculate vertical centering
@@ -363,3 +366,9 @@ func (p *SelectPopup) RenderOverlay(bgLines []string, startX, startY int) []stri
 
 	return result
 }
+
+// View renders the popup centered on a full screen
+func (p *SelectPopup) View(screenWidth, screenHeight int) string {
+	lines := p.Render()
+	return centerOnScreen(lines, lipgloss.Width(lines[0]), screenWidth, screenHeight)
+}
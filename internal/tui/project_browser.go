@@ -0,0 +1,727 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ProjectBrowserField int
+
+const (
+	BrowserFieldURL ProjectBrowserField = iota
+	BrowserFieldToken
+	BrowserFieldGroup
+	BrowserFieldConnect
+)
+
+// ProjectBrowserModel lets the user browse the groups and projects of a GitLab instance
+// and add several projects as configs sharing one token
+type ProjectBrowserModel struct {
+	width  int
+	height int
+
+	// Connect step
+	connected    bool
+	focusedField ProjectBrowserField
+	urlInput     textinput.Model
+	tokenInput   textinput.Model
+	groupInput   textinput.Model
+
+	// Browse step
+	group        *models.Group // Group whose projects are listed, nil while listing groups
+	groups       []models.Group
+	projects     []models.Project
+	nextPage     int
+	loading      bool
+	search       textinput.Model
+	searching    bool
+	query        string // Submitted search query
+	cursor       int
+	scrollOffset int
+
+	// Selection, kept while moving between groups
+	selected      map[int]models.Project
+	selectedOrder []int
+	existing      map[string]bool // Project URLs that are already configured
+}
+
+// allProjectsGroup is the pseudo group listing every project the user is a member of
+var allProjectsGroup = models.Group{ID: 0, Name: "All my projects"}
+
+func NewProjectBrowserModel() ProjectBrowserModel {
+	urlInput := textinput.New()
+	urlInput.Placeholder = "https://gitlab.com"
+	urlInput.CharLimit = 200
+	urlInput.Width = 50
+	urlInput.Cursor.Style = CursorStyle
+
+	tokenInput := textinput.New()
+	tokenInput.Placeholder = "glpat-..."
+	tokenInput.CharLimit = 100
+	tokenInput.Width = 50
+	tokenInput.EchoMode = textinput.EchoPassword
+	tokenInput.Cursor.Style = CursorStyle
+
+	groupInput := textinput.New()
+	groupInput.Placeholder = "Optional, e.g. Backend"
+	groupInput.CharLimit = 50
+	groupInput.Width = 40
+	groupInput.Cursor.Style = CursorStyle
+
+	search := textinput.New()
+	search.Placeholder = "Search..."
+	search.CharLimit = 60
+	search.Width = 30
+
+	return ProjectBrowserModel{
+		urlInput:   urlInput,
+		tokenInput: tokenInput,
+		groupInput: groupInput,
+		search:     search,
+		selected:   make(map[int]models.Project),
+	}
+}
+
+func (m *ProjectBrowserModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Reset starts a new browsing session, prefilled with the instance and token of an existing config
+func (m *ProjectBrowserModel) Reset(baseURL, token string, configs []models.Config) {
+	m.connected = false
+	m.loading = false
+	m.urlInput.SetValue(baseURL)
+	m.tokenInput.SetValue(token)
+	m.groupInput.SetValue("")
+	m.group = nil
+	m.groups = nil
+	m.projects = nil
+	m.nextPage = 0
+	m.searching = false
+	m.query = ""
+	m.search.SetValue("")
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.selected = make(map[int]models.Project)
+	m.selectedOrder = nil
+
+	m.existing = make(map[string]bool)
+	for _, config := range configs {
+		m.existing[strings.TrimSuffix(config.ProjectURL, "/")] = true
+	}
+
+	m.blurInputs()
+	m.focusedField = BrowserFieldURL
+	if baseURL != "" {
+		m.focusedField = BrowserFieldToken
+		if token != "" {
+			m.focusedField = BrowserFieldConnect
+		}
+	}
+	m.focusCurrent()
+}
+
+// IsTyping returns true while a text input has the focus
+func (m ProjectBrowserModel) IsTyping() bool {
+	return m.searching || (!m.connected && m.focusedField != BrowserFieldConnect)
+}
+
+// SetGroups shows a loaded page of groups, ignoring pages of an outdated listing
+func (m *ProjectBrowserModel) SetGroups(groups []models.Group, search string, page, nextPage int) {
+	if m.group != nil || search != m.query {
+		return
+	}
+	m.connected = true
+	m.loading = false
+	if page <= 1 {
+		m.groups = nil
+		m.cursor = 0
+		m.scrollOffset = 0
+	}
+	m.groups = append(m.groups, groups...)
+	m.nextPage = nextPage
+}
+
+// SetProjects shows a loaded page of projects, ignoring pages of an outdated listing
+func (m *ProjectBrowserModel) SetProjects(projects []models.Project, groupID int, search string, page, nextPage int) {
+	if m.group == nil || m.group.ID != groupID || search != m.query {
+		return
+	}
+	m.loading = false
+	if page <= 1 {
+		m.projects = nil
+		m.cursor = 0
+		m.scrollOffset = 0
+	}
+	m.projects = append(m.projects, projects...)
+	m.nextPage = nextPage
+}
+
+// LoadFailed stops waiting for a page that could not be loaded
+func (m *ProjectBrowserModel) LoadFailed() {
+	m.loading = false
+}
+
+// baseURL returns the normalized instance URL
+func (m ProjectBrowserModel) baseURL() string {
+	baseURL := strings.TrimSuffix(strings.TrimSpace(m.urlInput.Value()), "/")
+	if baseURL != "" && !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return baseURL
+}
+
+// itemCount returns the number of rows in the current listing
+func (m ProjectBrowserModel) itemCount() int {
+	if m.group == nil {
+		return len(m.groups) + 1 // "All my projects" comes first
+	}
+	return len(m.projects)
+}
+
+// load requests the given page of the current listing
+func (m *ProjectBrowserModel) load(page int) tea.Cmd {
+	m.loading = true
+	baseURL := m.baseURL()
+	token := strings.TrimSpace(m.tokenInput.Value())
+	search := m.query
+
+	if m.group == nil {
+		return func() tea.Msg {
+			return loadBrowseGroupsMsg{baseURL: baseURL, token: token, search: search, page: page}
+		}
+	}
+	groupID := m.group.ID
+	return func() tea.Msg {
+		return loadBrowseProjectsMsg{baseURL: baseURL, token: token, groupID: groupID, search: search, page: page}
+	}
+}
+
+// loadMore fetches the next page once the cursor reaches the end of the loaded rows
+func (m *ProjectBrowserModel) loadMore() tea.Cmd {
+	if m.loading || m.nextPage == 0 || m.cursor < m.itemCount()-1 {
+		return nil
+	}
+	return m.load(m.nextPage)
+}
+
+func (m ProjectBrowserModel) Update(msg tea.Msg) (ProjectBrowserModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.connected {
+			return m.updateConnect(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+func (m ProjectBrowserModel) updateConnect(msg tea.KeyMsg) (ProjectBrowserModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, Navigate(ScreenConfigList)
+	case "tab", "down":
+		m.moveFocus(1)
+	case "shift+tab", "up":
+		m.moveFocus(-1)
+	case "enter", "ctrl+s":
+		if m.focusedField != BrowserFieldConnect && msg.String() == "enter" {
+			m.moveFocus(1)
+			return m, nil
+		}
+		return m.connect()
+	default:
+		var cmd tea.Cmd
+		switch m.focusedField {
+		case BrowserFieldURL:
+			m.urlInput, cmd = m.urlInput.Update(msg)
+		case BrowserFieldToken:
+			m.tokenInput, cmd = m.tokenInput.Update(msg)
+		case BrowserFieldGroup:
+			m.groupInput, cmd = m.groupInput.Update(msg)
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m ProjectBrowserModel) connect() (ProjectBrowserModel, tea.Cmd) {
+	if m.baseURL() == "" || strings.TrimSpace(m.tokenInput.Value()) == "" {
+		return m, func() tea.Msg {
+			return errMsg{fmt.Errorf("instance URL and token are required")}
+		}
+	}
+	if m.loading {
+		return m, nil
+	}
+	m.group = nil
+	m.query = ""
+	m.search.SetValue("")
+	return m, m.load(1)
+}
+
+func (m *ProjectBrowserModel) moveFocus(delta int) {
+	m.blurInputs()
+	fields := int(BrowserFieldConnect) + 1
+	m.focusedField = ProjectBrowserField((int(m.focusedField) + delta + fields) % fields)
+	m.focusCurrent()
+}
+
+func (m *ProjectBrowserModel) blurInputs() {
+	m.urlInput.Blur()
+	m.tokenInput.Blur()
+	m.groupInput.Blur()
+}
+
+func (m *ProjectBrowserModel) focusCurrent() {
+	switch m.focusedField {
+	case BrowserFieldURL:
+		m.urlInput.Focus()
+	case BrowserFieldToken:
+		m.tokenInput.Focus()
+	case BrowserFieldGroup:
+		m.groupInput.Focus()
+	}
+}
+
+func (m ProjectBrowserModel) updateBrowse(msg tea.KeyMsg) (ProjectBrowserModel, tea.Cmd) {
+	if m.searching {
+		switch msg.String() {
+		case "enter":
+			m.searching = false
+			m.search.Blur()
+			m.query = strings.TrimSpace(m.search.Value())
+			return m, m.load(1)
+		case "esc":
+			m.searching = false
+			m.search.Blur()
+			m.search.SetValue(m.query)
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		m.adjustScroll()
+	case "down", "j":
+		if m.cursor < m.itemCount()-1 {
+			m.cursor++
+		}
+		m.adjustScroll()
+		return m, m.loadMore()
+	case "/":
+		m.searching = true
+		m.search.Focus()
+		return m, textinput.Blink
+	case "enter":
+		if m.group == nil {
+			group := allProjectsGroup
+			if m.cursor > 0 {
+				group = m.groups[m.cursor-1]
+			}
+			m.group = &group
+			m.projects = nil
+			m.query = ""
+			m.search.SetValue("")
+			m.cursor = 0
+			m.scrollOffset = 0
+			return m, m.load(1)
+		}
+		m.toggleProject()
+	case " ":
+		m.toggleProject()
+	case "a":
+		m.toggleAllProjects()
+	case "ctrl+s":
+		return m.addSelected()
+	case "esc", "backspace":
+		if m.group != nil {
+			// Back to the group list
+			m.group = nil
+			m.groups = nil
+			m.query = ""
+			m.search.SetValue("")
+			return m, m.load(1)
+		}
+		m.connected = false
+		m.loading = false
+	}
+	return m, nil
+}
+
+// toggleProject selects or deselects the project under the cursor
+func (m *ProjectBrowserModel) toggleProject() {
+	if m.group == nil || m.cursor >= len(m.projects) {
+		return
+	}
+	project := m.projects[m.cursor]
+	if m.existing[strings.TrimSuffix(project.WebURL, "/")] {
+		return
+	}
+	if _, ok := m.selected[project.ID]; ok {
+		m.deselect(project.ID)
+	} else {
+		m.selected[project.ID] = project
+		m.selectedOrder = append(m.selectedOrder, project.ID)
+	}
+}
+
+// toggleAllProjects selects all loaded projects, or deselects them if they are all selected
+func (m *ProjectBrowserModel) toggleAllProjects() {
+	if m.group == nil {
+		return
+	}
+	allSelected := true
+	for _, project := range m.projects {
+		if _, ok := m.selected[project.ID]; !ok && !m.existing[strings.TrimSuffix(project.WebURL, "/")] {
+			allSelected = false
+			break
+		}
+	}
+	for _, project := range m.projects {
+		_, ok := m.selected[project.ID]
+		switch {
+		case allSelected && ok:
+			m.deselect(project.ID)
+		case !allSelected && !ok && !m.existing[strings.TrimSuffix(project.WebURL, "/")]:
+			m.selected[project.ID] = project
+			m.selectedOrder = append(m.selectedOrder, project.ID)
+		}
+	}
+}
+
+func (m *ProjectBrowserModel) deselect(id int) {
+	delete(m.selected, id)
+	for i, selectedID := range m.selectedOrder {
+		if selectedID == id {
+			m.selectedOrder = append(m.selectedOrder[:i], m.selectedOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// addSelected creates configs for the selected projects, all sharing the same token
+func (m ProjectBrowserModel) addSelected() (ProjectBrowserModel, tea.Cmd) {
+	if len(m.selectedOrder) == 0 {
+		return m, func() tea.Msg {
+			return errMsg{fmt.Errorf("no projects selected, press Space to select")}
+		}
+	}
+
+	baseURL := m.baseURL()
+	token := strings.TrimSpace(m.tokenInput.Value())
+	group := strings.TrimSpace(m.groupInput.Value())
+
+	var configs []models.Config
+	for _, id := range m.selectedOrder {
+		project := m.selected[id]
+		configs = append(configs, models.Config{
+			Name:       project.Name,
+			ProjectURL: project.WebURL,
+			Token:      token,
+			ProjectID:  project.ID,
+			BaseURL:    baseURL,
+			AuthType:   models.AuthTypeToken,
+			Group:      group,
+		})
+	}
+
+	return m, func() tea.Msg {
+		return addProjectsMsg{configs: configs}
+	}
+}
+
+// visibleRows returns how many list rows fit on screen
+func (m ProjectBrowserModel) visibleRows() int {
+	// Height minus search row, empty line, header row and the bottom border
+	return maxInt(1, m.height-5)
+}
+
+func (m *ProjectBrowserModel) adjustScroll() {
+	visible := m.visibleRows()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+}
+
+func (m ProjectBrowserModel) View() string {
+	leftWidth := (m.width * 2) / 3
+	rightWidth := m.width - leftWidth - 1
+
+	var leftLines, rightLines []string
+	if m.connected {
+		leftLines = m.renderList(leftWidth)
+		rightLines = m.renderSelectionPanel(rightWidth)
+	} else {
+		leftLines = m.renderConnectForm(leftWidth)
+		rightLines = m.renderHelpPanel(rightWidth)
+	}
+
+	var result []string
+	maxLines := maxInt(len(leftLines), len(rightLines))
+
+	for i := 0; i < maxLines; i++ {
+		left := ""
+		if i < len(leftLines) {
+			left = leftLines[i]
+		}
+		left = padToWidth(left, leftWidth)
+
+		right := ""
+		if i < len(rightLines) {
+			right = rightLines[i]
+		}
+		right = padToWidth(right, rightWidth)
+
+		result = append(result, left+"│"+right)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (m ProjectBrowserModel) renderConnectForm(width int) []string {
+	label := LabelStyle
+	selected := SelectedStyle
+
+	var lines []string
+
+	title := " 🔭 Browse GitLab "
+	borderLen := width - lipgloss.Width(title) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+title+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	labelWidth := 20
+
+	content = append(content, label.Render(padRight("  Instance URL", labelWidth))+" "+m.urlInput.View())
+	content = append(content, "") // Gap
+	content = append(content, label.Render(padRight("  Access Token", labelWidth))+" "+m.tokenInput.View())
+	content = append(content, "") // Gap
+	content = append(content, label.Render(padRight("  Add to Group", labelWidth))+" "+m.groupInput.View())
+	content = append(content, "") // Gap
+	content = append(content, "") // Extra gap before buttons
+
+	connectBtn := " 🔌 Connect "
+	if m.loading {
+		connectBtn = " ⟳ Connecting... "
+	}
+	if m.focusedField == BrowserFieldConnect {
+		connectBtn = selected.Render(connectBtn)
+	}
+	content = append(content, "  "+connectBtn)
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+func (m ProjectBrowserModel) renderHelpPanel(width int) []string {
+	heading := TitleStyle
+	highlight := YellowStyle
+	muted := GrayStyle
+
+	var lines []string
+
+	title := " Help "
+	borderLen := width - lipgloss.Width(title) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+title+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	content = append(content, heading.Render("Browse Projects"))
+	content = append(content, "")
+	content = append(content, "Connect to a GitLab instance, then pick")
+	content = append(content, "one or many projects from your groups.")
+	content = append(content, "All added configs share the same token.")
+	content = append(content, "")
+	content = append(content, heading.Render("Access Token"))
+	content = append(content, "")
+	content = append(content, "Needs the "+highlight.Render("api")+" scope. Group tokens only")
+	content = append(content, "see the projects of their group.")
+	content = append(content, "")
+	content = append(content, heading.Render("Add to Group"))
+	content = append(content, "")
+	content = append(content, "Folder the new configs are shown in,")
+	content = append(content, muted.Render("leave empty to keep them ungrouped."))
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+func (m ProjectBrowserModel) renderList(width int) []string {
+	var lines []string
+	indent := "   "
+
+	// Search row with the current location
+	location := "Groups"
+	if m.group != nil {
+		location = m.group.Name
+		if m.group.FullPath != "" {
+			location = m.group.FullPath
+		}
+	}
+	searchRow := indent + TitleStyle.Render("🔍 ") + m.search.View()
+	loaded := len(m.projects)
+	if m.group == nil {
+		loaded = len(m.groups)
+	}
+	status := fmt.Sprintf("  %s · %d loaded", location, loaded)
+	if m.nextPage > 0 {
+		status += ", more available"
+	}
+	lines = append(lines, searchRow+GrayStyle.Render(status))
+	lines = append(lines, "")
+
+	header := indent + "Group"
+	if m.group != nil {
+		header = indent + padRight("", 4) + "Project"
+	}
+	lines = append(lines, TitleStyle.Render(header))
+
+	visible := m.visibleRows()
+	end := minInt(m.scrollOffset+visible, m.itemCount())
+	for i := m.scrollOffset; i < end; i++ {
+		var text string
+		configured := false
+
+		if m.group == nil {
+			if i == 0 {
+				text = "★ " + allProjectsGroup.Name
+			} else {
+				text = "📁 " + m.groups[i-1].FullPath
+			}
+		} else {
+			project := m.projects[i]
+			configured = m.existing[strings.TrimSuffix(project.WebURL, "/")]
+			check := "[ ] "
+			if _, ok := m.selected[project.ID]; ok {
+				check = "[✓] "
+			} else if configured {
+				check = "[-] "
+			}
+			text = check + project.PathWithNamespace
+		}
+		text = indent + truncateStr(text, width-len(indent)-2)
+
+		switch {
+		case i == m.cursor:
+			lines = append(lines, SelectedStyle.Render(padRight(text, width-1)))
+		case configured:
+			lines = append(lines, GrayStyle.Render(text+" (configured)"))
+		default:
+			lines = append(lines, text)
+		}
+	}
+
+	if m.loading {
+		lines = append(lines, GrayStyle.Render(indent+"⟳ Loading..."))
+	} else if m.itemCount() == 0 {
+		lines = append(lines, GrayStyle.Render(indent+"Nothing found"))
+	}
+
+	return lines
+}
+
+func (m ProjectBrowserModel) renderSelectionPanel(width int) []string {
+	title := TitleStyle
+	label := YellowStyle.Bold(true)
+
+	var lines []string
+
+	boxTitle := fmt.Sprintf(" Selected (%d) ", len(m.selectedOrder))
+	borderLen := width - lipgloss.Width(boxTitle) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+boxTitle+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	contentWidth := width - 6
+
+	// Item under the cursor
+	if m.group != nil && m.cursor < len(m.projects) {
+		project := m.projects[m.cursor]
+		content = append(content, title.Render(truncateStr(project.Name, contentWidth)))
+		content = append(content, "")
+		content = append(content, label.Render("Path"))
+		content = append(content, "  "+truncateStr(project.PathWithNamespace, contentWidth-2))
+		if project.Description != "" {
+			content = append(content, "")
+			content = append(content, label.Render("Description"))
+			for _, line := range wrapText(project.Description, contentWidth-2) {
+				content = append(content, "  "+line)
+			}
+		}
+		content = append(content, "")
+	}
+
+	content = append(content, label.Render("To Add"))
+	if len(m.selectedOrder) == 0 {
+		content = append(content, GrayStyle.Render("  Space to select projects"))
+	}
+	// Keep the list within the panel
+	room := maxInt(1, m.height-len(content)-6)
+	for i, id := range m.selectedOrder {
+		if i == room-1 && len(m.selectedOrder) > room {
+			content = append(content, GrayStyle.Render(fmt.Sprintf("  ... and %d more", len(m.selectedOrder)-i)))
+			break
+		}
+		content = append(content, "  "+truncateStr(m.selected[id].PathWithNamespace, contentWidth-2))
+	}
+	if group := strings.TrimSpace(m.groupInput.Value()); group != "" {
+		content = append(content, "")
+		content = append(content, GrayStyle.Render("  Added to group \""+group+"\""))
+	}
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, BorderLeft+paddedLine+BorderRight)
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, BorderLeft+strings.Repeat(" ", width-2)+BorderRight)
+	}
+
+	lines = append(lines, BorderBottomLeft+strings.Repeat(BorderTop, width-2)+BorderBottomRight)
+
+	return lines
+}
@@ -3,15 +3,17 @@ package tui
 import (
 	"fmt"
 	"glcron/internal/models"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// QuickRunPipelinesListLimit defines how many pipelines to fetch from API
-// This value can be adjusted as needed
+// QuickRunPipelinesListLimit defines how many pipelines to fetch from API per page,
+// older ones are loaded when the cursor reaches the end of the list
 const QuickRunPipelinesListLimit = 15
 
 // Column widths for pipeline list
@@ -29,6 +31,7 @@ type QuickRunField int
 
 const (
 	QuickRunFieldBranch QuickRunField = iota
+	QuickRunFieldRunFields
 	QuickRunFieldVariables
 	QuickRunFieldStart
 	QuickRunFieldCancel
@@ -42,29 +45,50 @@ type QuickRunModel struct {
 	focusedField  QuickRunField
 	showingForm   bool
 	branch        string
-	branchIdx     int
-	branches      []string
+	refPicker     RefPicker
 	variables     []models.Variable
 	varInputs     []textinput.Model
 	focusedVarIdx int
+	rerunOf       int // Pipeline the form was prefilled from, 0 for a new run
+
+	// Inputs and variables declared in .gitlab-ci.yml at the branch
+	runFields     []runFieldInput
+	focusedRunIdx int
 
 	// Popup state
 	showingPopup bool
 	popupCursor  int
+	popupField   int // Run field whose options are listed, -1 for the ref picker
+
+	// Cancel confirmation
+	actionPopup   *ConfirmPopup
+	pendingAction pipelineActionMsg
 
 	// Pipeline list
 	pipelines        []models.PipelineWithJobs
 	selectedPipeline int
 	scrollOffset     int
+	nextPage         int // 0 once the last page is loaded
+	loadingMore      bool
+
+	// Filter pushed down to the pipelines API
+	filter        models.PipelineFilter
+	filterForm    PipelineFilterForm
+	showingFilter bool
 
+	// Automatic refresh
+	refreshSeq   int           // Only the latest scheduled refresh runs
+	idleInterval time.Duration // Grows while no pipeline runs
 }
 
 func NewQuickRunModel() QuickRunModel {
 	return QuickRunModel{
 		branch:       "main",
-		branches:     []string{"main", "master"},
 		showingForm:  false,
 		focusedField: QuickRunFieldBranch,
+		filterForm:   NewPipelineFilterForm(),
+		refPicker:    NewRefPicker(),
+		popupField:   -1,
 	}
 }
 
@@ -73,22 +97,193 @@ func (m *QuickRunModel) SetSize(width, height int) {
 	m.height = height
 }
 
-func (m *QuickRunModel) SetBranches(branches []string) {
-	m.branches = branches
-	// Find current branch in the list
-	for i, b := range m.branches {
-		if b == m.branch {
-			m.branchIdx = i
-			break
+// SetRef runs on a branch or tag picked, or typed in and checked against the repository
+func (m *QuickRunModel) SetRef(ref string) tea.Cmd {
+	m.showingPopup = false
+	if ref == m.branch {
+		return nil
+	}
+	m.branch = ref
+	return m.loadRunFields()
+}
+
+// SetPipelines shows a loaded page of pipelines, the first one replacing the list.
+// Pages loaded with another filter than the current one are ignored.
+func (m *QuickRunModel) SetPipelines(pipelines []models.PipelineWithJobs, filter models.PipelineFilter, page, nextPage int) {
+	if filter != m.filter {
+		return
+	}
+	m.loadingMore = false
+	m.nextPage = nextPage
+
+	if page <= 1 {
+		m.pipelines = pipelines
+	} else {
+		// New pipelines shift the pages, skip the ones already listed
+		known := make(map[int]bool, len(m.pipelines))
+		for _, p := range m.pipelines {
+			known[p.Pipeline.ID] = true
+		}
+		for _, p := range pipelines {
+			if !known[p.Pipeline.ID] {
+				m.pipelines = append(m.pipelines, p)
+			}
 		}
 	}
+
+	if m.selectedPipeline >= len(m.pipelines) && len(m.pipelines) > 0 {
+		m.selectedPipeline = len(m.pipelines) - 1
+	}
+	m.adjustScroll()
 }
 
-func (m *QuickRunModel) SetPipelines(pipelines []models.PipelineWithJobs) {
-	m.pipelines = pipelines
+// LoadFailed stops waiting for a page that could not be loaded
+func (m *QuickRunModel) LoadFailed() {
+	m.loadingMore = false
+}
+
+// Filter returns the filter of the pipeline list
+func (m *QuickRunModel) Filter() models.PipelineFilter {
+	return m.filter
+}
+
+// ClearFilter lists every pipeline again, used when another project is opened
+func (m *QuickRunModel) ClearFilter() {
+	m.filter = models.PipelineFilter{}
+	m.showingFilter = false
+}
+
+// IsTyping returns true while the run or filter form has the focus
+func (m QuickRunModel) IsTyping() bool {
+	return m.showingForm || m.showingFilter
+}
+
+// loadMore fetches the next page once the cursor reaches the end of the list
+func (m *QuickRunModel) loadMore() tea.Cmd {
+	if m.loadingMore || m.nextPage == 0 || m.selectedPipeline < len(m.pipelines)-1 {
+		return nil
+	}
+	m.loadingMore = true
+	page := m.nextPage
+	return func() tea.Msg {
+		return loadMorePipelinesMsg{page: page}
+	}
+}
+
+// MergePipelines replaces the pipelines that changed, adds the new ones and drops the removed
+// ones, keeping the list newest first and the same pipeline selected. Changes found with
+// another filter than the current one are ignored.
+func (m *QuickRunModel) MergePipelines(changed []models.PipelineWithJobs, removed []int, filter models.PipelineFilter) {
+	if (len(changed) == 0 && len(removed) == 0) || filter != m.filter {
+		return
+	}
+
+	selectedID := 0
+	if m.selectedPipeline < len(m.pipelines) {
+		selectedID = m.pipelines[m.selectedPipeline].Pipeline.ID
+	}
+
+	byID := make(map[int]int, len(m.pipelines))
+	for i, p := range m.pipelines {
+		byID[p.Pipeline.ID] = i
+	}
+	for _, p := range changed {
+		if i, ok := byID[p.Pipeline.ID]; ok {
+			m.pipelines[i] = p
+		} else {
+			byID[p.Pipeline.ID] = len(m.pipelines)
+			m.pipelines = append(m.pipelines, p)
+		}
+	}
+	if len(removed) > 0 {
+		drop := make(map[int]bool, len(removed))
+		for _, id := range removed {
+			drop[id] = true
+		}
+		kept := m.pipelines[:0]
+		for _, p := range m.pipelines {
+			if !drop[p.Pipeline.ID] {
+				kept = append(kept, p)
+			}
+		}
+		m.pipelines = kept
+	}
+
+	sort.Slice(m.pipelines, func(i, j int) bool {
+		return m.pipelines[i].Pipeline.ID > m.pipelines[j].Pipeline.ID
+	})
+
+	for i, p := range m.pipelines {
+		if p.Pipeline.ID == selectedID {
+			m.selectedPipeline = i
+			break
+		}
+	}
 	if m.selectedPipeline >= len(m.pipelines) && len(m.pipelines) > 0 {
 		m.selectedPipeline = len(m.pipelines) - 1
 	}
+	m.adjustScroll()
+}
+
+// PipelineUpdates returns when each listed pipeline was last updated, to tell which ones changed
+func (m *QuickRunModel) PipelineUpdates() map[int]time.Time {
+	updates := make(map[int]time.Time, len(m.pipelines))
+	for _, p := range m.pipelines {
+		if p.Pipeline.UpdatedAt != nil {
+			updates[p.Pipeline.ID] = *p.Pipeline.UpdatedAt
+		}
+	}
+	return updates
+}
+
+// LastUpdate returns when the listed pipelines were last updated, nil if none is listed
+func (m *QuickRunModel) LastUpdate() *time.Time {
+	var last *time.Time
+	for _, p := range m.pipelines {
+		if p.Pipeline.UpdatedAt != nil && (last == nil || p.Pipeline.UpdatedAt.After(*last)) {
+			last = p.Pipeline.UpdatedAt
+		}
+	}
+	return last
+}
+
+// RunningPipelineIDs returns the listed pipelines that can still change
+func (m *QuickRunModel) RunningPipelineIDs() []int {
+	var ids []int
+	for _, p := range m.pipelines {
+		if p.Pipeline.IsRunning() {
+			ids = append(ids, p.Pipeline.ID)
+		}
+	}
+	return ids
+}
+
+// NextRefresh returns when to refresh the list next and the sequence number of that refresh.
+// Running pipelines are refreshed every base interval, once all finished the interval doubles
+// up to PipelineIdleRefreshInterval as only new pipelines can show up.
+func (m *QuickRunModel) NextRefresh(base time.Duration) (time.Duration, int) {
+	m.refreshSeq++
+	if len(m.RunningPipelineIDs()) > 0 {
+		m.idleInterval = 0
+		return base, m.refreshSeq
+	}
+
+	if m.idleInterval == 0 {
+		m.idleInterval = base
+	}
+	m.idleInterval *= 2
+	if m.idleInterval > PipelineIdleRefreshInterval {
+		m.idleInterval = PipelineIdleRefreshInterval
+	}
+	if m.idleInterval < base {
+		m.idleInterval = base
+	}
+	return m.idleInterval, m.refreshSeq
+}
+
+// RefreshSeq returns the sequence number of the latest scheduled refresh
+func (m *QuickRunModel) RefreshSeq() int {
+	return m.refreshSeq
 }
 
 func (m *QuickRunModel) GetBranch() string {
@@ -102,13 +297,23 @@ func (m *QuickRunModel) GetVariables() []models.Variable {
 		if val != "" {
 			key, value := parseKeyValue(val)
 			if key != "" {
-				vars = append(vars, models.Variable{Key: key, Value: value, VariableType: "env_var"})
+				vars = append(vars, models.Variable{Key: key, Value: value, VariableType: m.variableType(key)})
 			}
 		}
 	}
 	return vars
 }
 
+// variableType keeps the type of a variable copied from a previous pipeline, "env_var" otherwise
+func (m *QuickRunModel) variableType(key string) string {
+	for _, v := range m.variables {
+		if v.Key == key && v.VariableType != "" {
+			return v.VariableType
+		}
+	}
+	return "env_var"
+}
+
 func (m *QuickRunModel) rebuildVarInputs() {
 	m.varInputs = make([]textinput.Model, len(m.variables)+1)
 	for i, v := range m.variables {
@@ -128,9 +333,12 @@ func (m *QuickRunModel) rebuildVarInputs() {
 func (m *QuickRunModel) Reset() {
 	m.showingForm = false
 	m.showingPopup = false
+	m.showingFilter = false
+	m.actionPopup = nil
 	m.focusedField = QuickRunFieldBranch
 	m.selectedPipeline = 0
 	m.scrollOffset = 0
+	m.idleInterval = 0
 	// Don't reset variables - keep them for next run
 }
 
@@ -159,15 +367,45 @@ func (m *QuickRunModel) getVisiblePipelineRows() int {
 	return m.height - 7
 }
 
-func (m *QuickRunModel) ShowForm() {
+func (m *QuickRunModel) ShowForm() tea.Cmd {
 	m.showingForm = true
 	m.focusedField = QuickRunFieldBranch
+	m.rerunOf = 0
 	m.rebuildVarInputs()
+	return m.loadRunFields()
+}
+
+// ShowRerunForm opens the run form prefilled with the ref and variables of a previous pipeline
+func (m *QuickRunModel) ShowRerunForm(pipeline models.Pipeline, variables []models.Variable) tea.Cmd {
+	m.branch = pipeline.Ref
+	m.variables = variables
+	m.showingForm = true
+	m.showingFilter = false
+	m.rerunOf = pipeline.ID
+	m.rebuildVarInputs()
+
+	// Ready to tweak the first variable, or to type one
+	m.blurCurrent()
+	m.focusedField = QuickRunFieldVariables
+	m.focusedVarIdx = 0
+	m.focusCurrent()
+	return m.loadRunFields()
 }
 
 func (m QuickRunModel) Update(msg tea.Msg) (QuickRunModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.actionPopup != nil {
+			closed, confirmed := handleConfirmKey(m.actionPopup, msg.String())
+			if closed {
+				m.actionPopup = nil
+			}
+			if confirmed {
+				return m, m.pendingAction.Cmd()
+			}
+			return m, nil
+		}
+
 		if m.showingPopup {
 			return m.handlePopupKey(msg)
 		}
@@ -176,6 +414,12 @@ func (m QuickRunModel) Update(msg tea.Msg) (QuickRunModel, tea.Cmd) {
 			return m.handleFormKey(msg)
 		}
 
+		if m.showingFilter {
+			var cmd tea.Cmd
+			m.filterForm, cmd = m.filterForm.Update(msg)
+			return m, cmd
+		}
+
 		// Main view (pipeline list)
 		switch msg.String() {
 		case "q":
@@ -183,7 +427,41 @@ func (m QuickRunModel) Update(msg tea.Msg) (QuickRunModel, tea.Cmd) {
 		case "esc":
 			return m, Navigate(ScreenScheduleList)
 		case "R":
-			m.ShowForm()
+			cmd := m.ShowForm()
+			return m, cmd
+		case "r":
+			if m.selectedPipeline < len(m.pipelines) {
+				pipeline := m.pipelines[m.selectedPipeline].Pipeline
+				return m, func() tea.Msg {
+					return rerunPipelineMsg{pipeline: pipeline}
+				}
+			}
+		case "f":
+			m.filterForm.SetFilter(m.filter)
+			m.showingFilter = true
+		case "F":
+			if !m.filter.IsEmpty() {
+				return m.applyFilter(models.PipelineFilter{})
+			}
+		case "w":
+			return m, func() tea.Msg {
+				return toggleWatchMsg{}
+			}
+		case "enter":
+			if m.selectedPipeline < len(m.pipelines) {
+				pipeline := m.pipelines[m.selectedPipeline].Pipeline
+				return m, NavigateToPipeline(&pipeline)
+			}
+		case "l":
+			if m.selectedPipeline < len(m.pipelines) {
+				if job := logJob(m.pipelines[m.selectedPipeline].Jobs); job != nil {
+					return m, NavigateToJobLog(job)
+				}
+			}
+		case "x":
+			return m.startAction(actionCancelPipeline)
+		case "t":
+			return m.startAction(actionRetryPipeline)
 		case "u":
 			// Manual refresh (status shown via global log panel)
 			return m, func() tea.Msg {
@@ -199,13 +477,75 @@ func (m QuickRunModel) Update(msg tea.Msg) (QuickRunModel, tea.Cmd) {
 				m.selectedPipeline++
 				m.adjustScroll()
 			}
+			cmd := m.loadMore()
+			return m, cmd
+		}
+
+	case pipelineFilterAppliedMsg:
+		return m.applyFilter(msg.filter)
+
+	case pipelineFilterClosedMsg:
+		m.showingFilter = false
+
+	case refSearchMsg, refsLoadedMsg:
+		if m.showingPopup && m.popupField < 0 {
+			var cmd tea.Cmd
+			m.refPicker, cmd = m.refPicker.Update(msg)
+			return m, cmd
 		}
 	}
 
 	return m, nil
 }
 
+// applyFilter closes the filter form and reloads the list from its first page
+func (m QuickRunModel) applyFilter(filter models.PipelineFilter) (QuickRunModel, tea.Cmd) {
+	m.showingFilter = false
+	m.filter = filter
+	m.pipelines = nil
+	m.nextPage = 0
+	m.loadingMore = false
+	m.selectedPipeline = 0
+	m.scrollOffset = 0
+	return m, func() tea.Msg {
+		return refreshPipelinesMsg{}
+	}
+}
+
+// startAction runs an action on the selected pipeline, asking first if it cancels anything
+func (m QuickRunModel) startAction(action pipelineAction) (QuickRunModel, tea.Cmd) {
+	if m.selectedPipeline >= len(m.pipelines) {
+		return m, nil
+	}
+
+	msg, warning := newPipelineAction(action, &m.pipelines[m.selectedPipeline].Pipeline)
+	if warning != nil {
+		return m, warning
+	}
+	if msg.NeedsConfirmation() {
+		m.pendingAction = msg
+		m.actionPopup = msg.ConfirmPopup()
+		return m, nil
+	}
+	return m, msg.Cmd()
+}
+
+// popupItems returns the options of the run field the popup lists
+func (m QuickRunModel) popupItems() []string {
+	if m.popupField >= 0 && m.popupField < len(m.runFields) {
+		return m.runFields[m.popupField].field.Options
+	}
+	return nil
+}
+
 func (m QuickRunModel) handlePopupKey(msg tea.KeyMsg) (QuickRunModel, tea.Cmd) {
+	if msg.String() != "esc" && m.popupField < 0 {
+		var cmd tea.Cmd
+		m.refPicker, cmd = m.refPicker.Update(msg)
+		return m, cmd
+	}
+
+	items := m.popupItems()
 	switch msg.String() {
 	case "esc":
 		m.showingPopup = false
@@ -214,13 +554,15 @@ func (m QuickRunModel) handlePopupKey(msg tea.KeyMsg) (QuickRunModel, tea.Cmd) {
 			m.popupCursor--
 		}
 	case "down", "j":
-		if m.popupCursor < len(m.branches)-1 {
+		if m.popupCursor < len(items)-1 {
 			m.popupCursor++
 		}
 	case "enter":
 		m.showingPopup = false
-		m.branch = m.branches[m.popupCursor]
-		m.branchIdx = m.popupCursor
+		if m.popupCursor >= len(items) {
+			return m, nil
+		}
+		m.runFields[m.popupField].input.SetValue(items[m.popupCursor])
 	}
 	return m, nil
 }
@@ -239,13 +581,17 @@ func (m QuickRunModel) handleFormKey(msg tea.KeyMsg) (QuickRunModel, tea.Cmd) {
 	case "left":
 		if m.focusedField == QuickRunFieldCancel {
 			m.focusedField = QuickRunFieldStart
-		} else if m.focusedField == QuickRunFieldVariables {
+		} else if f := m.focusedRunField(); f != nil && f.hasOptions() {
+			f.cycle(-1)
+		} else if m.focusedField == QuickRunFieldVariables || m.focusedField == QuickRunFieldRunFields {
 			return m.handleInputKey(msg)
 		}
 	case "right":
 		if m.focusedField == QuickRunFieldStart {
 			m.focusedField = QuickRunFieldCancel
-		} else if m.focusedField == QuickRunFieldVariables {
+		} else if f := m.focusedRunField(); f != nil && f.hasOptions() {
+			f.cycle(1)
+		} else if m.focusedField == QuickRunFieldVariables || m.focusedField == QuickRunFieldRunFields {
 			return m.handleInputKey(msg)
 		}
 	default:
@@ -255,12 +601,32 @@ func (m QuickRunModel) handleFormKey(msg tea.KeyMsg) (QuickRunModel, tea.Cmd) {
 	return m, nil
 }
 
+// focusedRunField returns the run field with the focus, nil if the focus is elsewhere
+func (m *QuickRunModel) focusedRunField() *runFieldInput {
+	if m.focusedField == QuickRunFieldRunFields && m.focusedRunIdx < len(m.runFields) {
+		return &m.runFields[m.focusedRunIdx]
+	}
+	return nil
+}
+
 func (m *QuickRunModel) nextField() {
 	m.blurCurrent()
 	switch m.focusedField {
 	case QuickRunFieldBranch:
-		m.focusedField = QuickRunFieldVariables
-		m.focusedVarIdx = 0
+		if len(m.runFields) > 0 {
+			m.focusedField = QuickRunFieldRunFields
+			m.focusedRunIdx = 0
+		} else {
+			m.focusedField = QuickRunFieldVariables
+			m.focusedVarIdx = 0
+		}
+	case QuickRunFieldRunFields:
+		if m.focusedRunIdx < len(m.runFields)-1 {
+			m.focusedRunIdx++
+		} else {
+			m.focusedField = QuickRunFieldVariables
+			m.focusedVarIdx = 0
+		}
 	case QuickRunFieldVariables:
 		if m.focusedVarIdx < len(m.varInputs)-1 {
 			m.focusedVarIdx++
@@ -280,9 +646,18 @@ func (m *QuickRunModel) prevField() {
 	switch m.focusedField {
 	case QuickRunFieldBranch:
 		m.focusedField = QuickRunFieldCancel
+	case QuickRunFieldRunFields:
+		if m.focusedRunIdx > 0 {
+			m.focusedRunIdx--
+		} else {
+			m.focusedField = QuickRunFieldBranch
+		}
 	case QuickRunFieldVariables:
 		if m.focusedVarIdx > 0 {
 			m.focusedVarIdx--
+		} else if len(m.runFields) > 0 {
+			m.focusedField = QuickRunFieldRunFields
+			m.focusedRunIdx = len(m.runFields) - 1
 		} else {
 			m.focusedField = QuickRunFieldBranch
 		}
@@ -299,19 +674,34 @@ func (m *QuickRunModel) blurCurrent() {
 	for i := range m.varInputs {
 		m.varInputs[i].Blur()
 	}
+	for i := range m.runFields {
+		m.runFields[i].input.Blur()
+	}
 }
 
 func (m *QuickRunModel) focusCurrent() {
 	if m.focusedField == QuickRunFieldVariables && m.focusedVarIdx < len(m.varInputs) {
 		m.varInputs[m.focusedVarIdx].Focus()
 	}
+	if f := m.focusedRunField(); f != nil && !f.hasOptions() {
+		f.input.Focus()
+	}
 }
 
 func (m QuickRunModel) handleEnter() (QuickRunModel, tea.Cmd) {
 	switch m.focusedField {
 	case QuickRunFieldBranch:
 		m.showingPopup = true
-		m.popupCursor = m.branchIdx
+		m.popupField = -1
+		return m, m.refPicker.Open(m.branch, false)
+	case QuickRunFieldRunFields:
+		if f := m.focusedRunField(); f != nil && f.hasOptions() {
+			m.showingPopup = true
+			m.popupField = m.focusedRunIdx
+			m.popupCursor = indexOf(f.field.Options, f.input.Value())
+		} else {
+			m.nextField()
+		}
 	case QuickRunFieldVariables:
 		if m.focusedVarIdx == len(m.varInputs)-1 {
 			val := m.varInputs[m.focusedVarIdx].Value()
@@ -330,11 +720,18 @@ func (m QuickRunModel) handleEnter() (QuickRunModel, tea.Cmd) {
 			}
 		}
 	case QuickRunFieldStart:
+		inputs, variables, err := m.runValues()
+		if err != nil {
+			return m, func() tea.Msg {
+				return statusMsg{text: err.Error(), msgType: string(LogTypeWarning)}
+			}
+		}
 		m.showingForm = false
 		return m, func() tea.Msg {
 			return quickRunPipelineMsg{
 				branch:    m.branch,
-				variables: m.GetVariables(),
+				variables: variables,
+				inputs:    inputs,
 			}
 		}
 	case QuickRunFieldCancel:
@@ -345,6 +742,10 @@ func (m QuickRunModel) handleEnter() (QuickRunModel, tea.Cmd) {
 
 func (m QuickRunModel) handleInputKey(msg tea.KeyMsg) (QuickRunModel, tea.Cmd) {
 	var cmd tea.Cmd
+	if f := m.focusedRunField(); f != nil && !f.hasOptions() {
+		f.input, cmd = f.input.Update(msg)
+		return m, cmd
+	}
 	if m.focusedField == QuickRunFieldVariables && m.focusedVarIdx < len(m.varInputs) {
 		m.varInputs[m.focusedVarIdx], cmd = m.varInputs[m.focusedVarIdx].Update(msg)
 		m.updateVariablesFromInputs()
@@ -359,7 +760,7 @@ func (m *QuickRunModel) updateVariablesFromInputs() {
 		if val != "" {
 			key, value := parseKeyValue(val)
 			if key != "" {
-				newVars = append(newVars, models.Variable{Key: key, Value: value, VariableType: "env_var"})
+				newVars = append(newVars, models.Variable{Key: key, Value: value, VariableType: m.variableType(key)})
 			}
 		}
 	}
@@ -375,9 +776,15 @@ func (m *QuickRunModel) updateVariablesFromInputs() {
 }
 
 func (m QuickRunModel) View() string {
+	if m.actionPopup != nil {
+		return m.actionPopup.View(m.width, m.height)
+	}
 	if m.showingForm {
 		return m.renderWithForm()
 	}
+	if m.showingFilter {
+		return m.renderWithFilter()
+	}
 	return m.renderPipelineList()
 }
 
@@ -387,7 +794,7 @@ func (m QuickRunModel) renderPipelineList() string {
 	// Title (status is shown in main app header)
 	title := " 🚀 Quick Pipeline Run "
 	titleWidth := lipgloss.Width(title)
-	
+
 	borderLen := m.width - titleWidth - 4
 	if borderLen < 0 {
 		borderLen = 0
@@ -398,9 +805,12 @@ func (m QuickRunModel) renderPipelineList() string {
 	instructionLine := " Press " + YellowStyle.Render("R") + " to start a new pipeline run, " +
 		YellowStyle.Render("u") + " to update, " +
 		YellowStyle.Render("↑↓") + " to navigate, " +
+		YellowStyle.Render("Enter") + " for jobs, " +
+		YellowStyle.Render("l") + " for the log, " +
+		YellowStyle.Render("x") + "/" + YellowStyle.Render("t") + " to cancel/retry, " +
 		YellowStyle.Render("Esc") + " to go back"
 	lines = append(lines, "│"+padToWidth(" "+instructionLine, m.width-2)+"│")
-	lines = append(lines, "│"+strings.Repeat(" ", m.width-2)+"│")
+	lines = append(lines, "│"+padToWidth(" "+GrayStyle.Render(m.listStatus()), m.width-2)+"│")
 
 	// Pipeline list header
 	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
@@ -422,7 +832,9 @@ func (m QuickRunModel) renderPipelineList() string {
 	// First, collect all content lines (without scrollbar)
 	var contentLines []string
 
-	if len(m.pipelines) == 0 {
+	if len(m.pipelines) == 0 && !m.filter.IsEmpty() {
+		contentLines = append(contentLines, "  "+GrayStyle.Render("No pipelines match the filter. Press F to clear it."))
+	} else if len(m.pipelines) == 0 {
 		contentLines = append(contentLines, "  "+GrayStyle.Render("No pipelines found. Press R to run a new pipeline."))
 	} else {
 		endIdx := m.scrollOffset + visibleRows
@@ -467,6 +879,20 @@ func (m QuickRunModel) renderPipelineList() string {
 	return strings.Join(lines, "\n")
 }
 
+// listStatus describes the filter and how much of the history is loaded
+func (m QuickRunModel) listStatus() string {
+	status := fmt.Sprintf("%d loaded", len(m.pipelines))
+	if m.loadingMore {
+		status += ", loading more..."
+	} else if m.nextPage > 0 {
+		status += ", more available"
+	}
+	if filter := describePipelineFilter(m.filter); filter != "" {
+		status = "Filter: " + filter + " · " + status
+	}
+	return status
+}
+
 // renderPipelineRowContent returns content lines without borders (for use with scrollbar)
 func (m QuickRunModel) renderPipelineRowContent(p models.PipelineWithJobs, selected bool) []string {
 	var lines []string
@@ -552,7 +978,7 @@ func getTriggerInfo(source string, user *models.User) string {
 		// }
 		return "@" + user.Username
 	}
-	
+
 	// Otherwise show the source type
 	return getSourceDisplayName(source)
 }
@@ -602,7 +1028,7 @@ func getPipelineDisplayName(source, name string) string {
 	if name != "" {
 		return name
 	}
-	
+
 	// Fallback based on source
 	switch source {
 	case "schedule":
@@ -677,7 +1103,7 @@ func (m QuickRunModel) renderStagesCompact(stages []models.StageInfo) string {
 // renderStagesWithTrigger renders stages with trigger indicator if pipeline was triggered
 func (m QuickRunModel) renderStagesWithTrigger(source string, stages []models.StageInfo) string {
 	stagesStr := m.renderStagesCompact(stages)
-	
+
 	// Check if pipeline was triggered by another pipeline/project
 	// Sources that indicate external trigger: "trigger", "pipeline", "parent_pipeline", "cross_project_pipeline"
 	switch source {
@@ -691,33 +1117,87 @@ func (m QuickRunModel) renderStagesWithTrigger(source string, stages []models.St
 
 func (m QuickRunModel) renderWithForm() string {
 	// Split vertically: top form, bottom pipeline list
-	formHeight := 15
+	content, focusLine := m.formContent()
+	// Grows with the fields of .gitlab-ci.yml, leaving a few pipelines visible
+	formHeight := minInt(maxInt(15, len(content)+3), maxInt(15, m.height-8))
 	listHeight := m.height - formHeight - 2
 
-	formLines := m.renderFormPanel(m.width, formHeight)
+	formLines := m.renderFormPanel(m.width, formHeight, content)
 	listLines := m.renderPipelineListPanel(m.width, listHeight)
 
 	var result []string
 	result = append(result, formLines...)
 	result = append(result, listLines...)
 
-	// If showing branch popup, overlay it
+	// If showing branch or options popup, overlay it under the field
 	if m.showingPopup {
-		return m.renderWithPopup(result)
+		return m.renderWithPopup(result, focusLine+2)
 	}
 
 	return strings.Join(result, "\n")
 }
 
-func (m QuickRunModel) renderWithPopup(bgLines []string) string {
+func (m QuickRunModel) renderWithFilter() string {
+	// Split vertically: top filter form, bottom pipeline list
+	formHeight := 12
+	listHeight := m.height - formHeight - 2
+
+	var result []string
+	result = append(result, m.filterForm.View(m.width, formHeight)...)
+	result = append(result, m.renderPipelineListPanel(m.width, listHeight)...)
+
+	return strings.Join(result, "\n")
+}
+
+func (m QuickRunModel) renderWithPopup(bgLines []string, popupStartY int) string {
+	popupStartX := 22
+	var popup []string
+	if m.popupField < 0 {
+		popup = m.refPicker.View(minInt(55, m.width-20))
+	} else {
+		popupStartX = 26
+		popup = m.renderOptionsPopup()
+	}
+
+	// Position popup - reconstruct lines completely to avoid ANSI slicing issues
+	// bgLines have format: │<content>│ (form panel borders)
+	// Main grid will wrap with additional │...│
+	innerWidth := m.width - 2 // Width inside the form panel borders (│ on each side)
+
+	var result []string
+	for i, line := range bgLines {
+		if i >= popupStartY && i < popupStartY+len(popup) {
+			popupIdx := i - popupStartY
+			popupLine := popup[popupIdx]
+			popupLineWidth := lipgloss.Width(popupLine)
+
+			// Build: │ + padding + popup + padding + │
+			beforePadding := strings.Repeat(" ", popupStartX)
+			remainingWidth := innerWidth - popupStartX - popupLineWidth
+			if remainingWidth < 0 {
+				remainingWidth = 0
+			}
+			afterPadding := strings.Repeat(" ", remainingWidth)
+			newLine := "│" + beforePadding + popupLine + afterPadding + "│"
+			result = append(result, newLine)
+		} else {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// renderOptionsPopup lists the options of a run field around the cursor
+func (m QuickRunModel) renderOptionsPopup() []string {
 	selectedStyle := lipgloss.NewStyle().Reverse(true)
+	items := m.popupItems()
 
-	// Build popup
-	title := " Branch "
+	title := " " + m.runFields[m.popupField].field.Name + " "
 	popupWidth := 40
-	for _, opt := range m.branches {
-		if len(opt)+6 > popupWidth {
-			popupWidth = len(opt) + 6
+	for _, opt := range items {
+		if w := lipgloss.Width(opt); w+6 > popupWidth {
+			popupWidth = w + 6
 		}
 	}
 	if popupWidth > m.width-20 {
@@ -738,8 +1218,8 @@ func (m QuickRunModel) renderWithPopup(bgLines []string) string {
 		start = 0
 	}
 	end := start + visibleItems
-	if end > len(m.branches) {
-		end = len(m.branches)
+	if end > len(items) {
+		end = len(items)
 		start = maxInt(0, end-visibleItems)
 	}
 
@@ -750,7 +1230,7 @@ func (m QuickRunModel) renderWithPopup(bgLines []string) string {
 	}
 
 	for i := start; i < end; i++ {
-		item := m.branches[i]
+		item := items[i]
 		if len(item) > popupWidth-4 {
 			item = item[:popupWidth-7] + "..."
 		}
@@ -763,52 +1243,23 @@ func (m QuickRunModel) renderWithPopup(bgLines []string) string {
 	}
 
 	// Show scroll indicator at bottom
-	if end < len(m.branches) {
+	if end < len(items) {
 		scrollLine := padRight("  ▼ more below", popupWidth-4)
 		popup = append(popup, "│ "+GrayStyle.Render(scrollLine)+" │")
 	}
 
 	popup = append(popup, "└"+strings.Repeat("─", popupWidth-2)+"┘")
-
-	// Position popup - reconstruct lines completely to avoid ANSI slicing issues
-	// bgLines have format: │<content>│ (form panel borders)
-	// Main grid will wrap with additional │...│
-	popupStartY := 2
-	popupStartX := 22
-	innerWidth := m.width - 2 // Width inside the form panel borders (│ on each side)
-
-	var result []string
-	for i, line := range bgLines {
-		if i >= popupStartY && i < popupStartY+len(popup) {
-			popupIdx := i - popupStartY
-			popupLine := popup[popupIdx]
-			popupLineWidth := lipgloss.Width(popupLine)
-
-			// Build: │ + padding + popup + padding + │
-			beforePadding := strings.Repeat(" ", popupStartX)
-			remainingWidth := innerWidth - popupStartX - popupLineWidth
-			if remainingWidth < 0 {
-				remainingWidth = 0
-			}
-			afterPadding := strings.Repeat(" ", remainingWidth)
-			newLine := "│" + beforePadding + popupLine + afterPadding + "│"
-			result = append(result, newLine)
-		} else {
-			result = append(result, line)
-		}
-	}
-
-	return strings.Join(result, "\n")
+	return popup
 }
 
-func (m QuickRunModel) renderFormPanel(width, height int) []string {
-	label := LabelStyle
-	selected := SelectedStyle
-
+func (m QuickRunModel) renderFormPanel(width, height int, content []string) []string {
 	var lines []string
 
 	// Title
 	title := " 🚀 New Pipeline Run "
+	if m.rerunOf > 0 {
+		title = fmt.Sprintf(" 🚀 Run Again (from #%d) ", m.rerunOf)
+	}
 	titleWidth := lipgloss.Width(title)
 	borderLen := width - titleWidth - 4
 	if borderLen < 0 {
@@ -816,7 +1267,32 @@ func (m QuickRunModel) renderFormPanel(width, height int) []string {
 	}
 	lines = append(lines, BorderTopLeft+BorderTop+title+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
 
+	// Render content with borders
+	for _, line := range content {
+		if len(lines) >= height-1 {
+			break
+		}
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	// Fill remaining space
+	for len(lines) < height-1 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "├"+strings.Repeat("─", width-2)+"┤")
+
+	return lines
+}
+
+// formContent returns the rows of the run form and the row of the focused field
+func (m QuickRunModel) formContent() ([]string, int) {
+	label := LabelStyle
+	selected := SelectedStyle
+
 	var content []string
+	focusLine := 0
 	labelWidth := 18
 
 	// Branch field
@@ -829,8 +1305,48 @@ func (m QuickRunModel) renderFormPanel(width, height int) []string {
 	}
 	content = append(content, "")
 
+	// Fields of .gitlab-ci.yml, inputs first
+	renderRunField := func(i int) {
+		f := m.runFields[i]
+		focused := m.focusedField == QuickRunFieldRunFields && m.focusedRunIdx == i
+		if focused {
+			focusLine = len(content)
+		}
+		name := padRight("    "+truncateStr(f.field.Name, 20), 26)
+		value := f.input.View()
+		if f.hasOptions() {
+			value = f.input.Value() + " ▾"
+			if focused {
+				value = selected.Render(" " + value + " ")
+			}
+		}
+		row := name + value
+		if f.field.Description != "" {
+			row = padToWidth(row, 60) + GrayStyle.Render(f.field.Description)
+		}
+		content = append(content, row)
+	}
+	hasInputs := false
+	for i, f := range m.runFields {
+		if f.field.Input {
+			if !hasInputs {
+				content = append(content, label.Render("  Inputs"))
+				hasInputs = true
+			}
+			renderRunField(i)
+		}
+	}
+	if hasInputs {
+		content = append(content, "")
+	}
+
 	// Variables section
 	content = append(content, label.Render("  Variables"))
+	for i, f := range m.runFields {
+		if !f.field.Input {
+			renderRunField(i)
+		}
+	}
 	for _, input := range m.varInputs {
 		varValue := input.View()
 		content = append(content, "    "+varValue)
@@ -848,20 +1364,7 @@ func (m QuickRunModel) renderFormPanel(width, height int) []string {
 		content = append(content, "  "+startBtn+"   "+cancelBtn)
 	}
 
-	// Render content with borders
-	for _, line := range content {
-		paddedLine := " " + padToWidth(line, width-4) + " "
-		lines = append(lines, "│"+paddedLine+"│")
-	}
-
-	// Fill remaining space
-	for len(lines) < height-1 {
-		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
-	}
-
-	lines = append(lines, "├"+strings.Repeat("─", width-2)+"┤")
-
-	return lines
+	return content, focusLine
 }
 
 func (m QuickRunModel) renderPipelineListPanel(width, height int) []string {
@@ -917,4 +1420,3 @@ func (m QuickRunModel) renderPipelineListPanel(width, height int) []string {
 
 	return lines
 }
-
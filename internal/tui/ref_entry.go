@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// validateRef asks to check a typed ref against the repository before using it
+func validateRef(ref string, forSchedule bool) tea.Cmd {
+	return func() tea.Msg {
+		return validateRefMsg{ref: ref, forSchedule: forSchedule}
+	}
+}
+
+// describeResolvedRef tells which ref a typed one resolved to
+func describeResolvedRef(ref *models.ResolvedRef) string {
+	sha := ref.SHA
+	if len(sha) > 8 {
+		sha = sha[:8]
+	}
+	if ref.Commit {
+		return fmt.Sprintf("Commit %s is the tip of %s %s", sha, ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("Using %s %s (%s)", ref.Kind, ref.Name, sha)
+}
@@ -0,0 +1,281 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// refSearchDelay waits for a pause in typing before searching the server
+const refSearchDelay = 250 * time.Millisecond
+
+// refItem is a branch or tag listed by the picker
+type refItem struct {
+	name      string
+	kind      string
+	protected bool
+	isDefault bool
+}
+
+// RefPicker lists the branches, then the tags of the project, searched on the server and
+// loaded a page at a time. Any other ref can be typed and is checked before it is used.
+type RefPicker struct {
+	search      textinput.Model
+	query       string // Query of the refs listed
+	searchSeq   int    // Only the latest typed search runs
+	current     string // Ref of the form, selected when listed
+	forSchedule bool
+
+	refs           []refItem
+	cursor         int
+	nextBranchPage int // 0 once the last branch page is loaded
+	nextTagPage    int // Only used once all branches are loaded
+	loading        bool
+	err            error
+}
+
+func NewRefPicker() RefPicker {
+	ti := textinput.New()
+	ti.Prompt = "🔍 "
+	ti.Placeholder = "Search, or type a branch, tag or commit SHA"
+	ti.CharLimit = 255
+	ti.Cursor.Style = CursorStyle
+
+	return RefPicker{search: ti}
+}
+
+// Open clears the search and loads the first page of refs
+func (p *RefPicker) Open(current string, forSchedule bool) tea.Cmd {
+	p.search.SetValue("")
+	p.search.Focus()
+	p.query = ""
+	p.searchSeq++
+	p.current = current
+	p.forSchedule = forSchedule
+	p.refs = nil
+	p.cursor = 0
+	p.nextBranchPage = 0
+	p.nextTagPage = 0
+	p.err = nil
+	return p.load(1, 0)
+}
+
+// load asks for a page of branches or of tags matching the query
+func (p *RefPicker) load(branchPage, tagPage int) tea.Cmd {
+	p.loading = true
+	query := p.query
+	return func() tea.Msg {
+		return loadRefsMsg{query: query, branchPage: branchPage, tagPage: tagPage}
+	}
+}
+
+// loadMore fetches the next page once the cursor reaches the last ref listed
+func (p *RefPicker) loadMore() tea.Cmd {
+	if p.loading || p.cursor < len(p.refs)-1 {
+		return nil
+	}
+	if p.nextBranchPage > 0 {
+		return p.load(p.nextBranchPage, 0)
+	}
+	if p.nextTagPage > 0 {
+		return p.load(0, p.nextTagPage)
+	}
+	return nil
+}
+
+// setRefs shows a loaded page, the first one replacing the list.
+// Pages loaded for another query than the current one are ignored.
+func (p *RefPicker) setRefs(msg refsLoadedMsg) {
+	if msg.query != p.query {
+		return
+	}
+	p.loading = false
+	p.err = msg.err
+	if msg.err != nil {
+		return
+	}
+
+	if msg.branchPage == 1 {
+		p.refs = nil
+		p.cursor = 0
+	}
+	for _, b := range msg.branches {
+		p.refs = append(p.refs, refItem{name: b.Name, kind: models.RefKindBranch, protected: b.Protected, isDefault: b.Default})
+	}
+	for _, t := range msg.tags {
+		p.refs = append(p.refs, refItem{name: t.Name, kind: models.RefKindTag, protected: t.Protected})
+	}
+	p.nextBranchPage = msg.nextBranchPage
+	p.nextTagPage = msg.nextTagPage
+
+	if msg.branchPage == 1 {
+		for i, ref := range p.refs {
+			if ref.name == p.current {
+				p.cursor = i
+				break
+			}
+		}
+	}
+}
+
+// typed returns the ref typed in the search, offered as the last entry
+func (p RefPicker) typed() string {
+	return strings.TrimSpace(p.search.Value())
+}
+
+// itemCount counts the refs listed and the entry using the typed ref
+func (p RefPicker) itemCount() int {
+	if p.typed() != "" {
+		return len(p.refs) + 1
+	}
+	return len(p.refs)
+}
+
+func (p RefPicker) Update(msg tea.Msg) (RefPicker, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+			return p, nil
+		case "down":
+			if p.cursor < p.itemCount()-1 {
+				p.cursor++
+			}
+			return p, p.loadMore()
+		case "enter":
+			if p.cursor < len(p.refs) {
+				ref := p.refs[p.cursor].name
+				forSchedule := p.forSchedule
+				return p, func() tea.Msg {
+					return refPickedMsg{ref: ref, forSchedule: forSchedule}
+				}
+			}
+			if typed := p.typed(); typed != "" {
+				return p, validateRef(typed, p.forSchedule)
+			}
+			return p, nil
+		}
+
+		before := p.search.Value()
+		var cmd tea.Cmd
+		p.search, cmd = p.search.Update(msg)
+		if p.search.Value() == before {
+			return p, cmd
+		}
+		p.cursor = minInt(p.cursor, maxInt(0, p.itemCount()-1))
+		p.searchSeq++
+		seq := p.searchSeq
+		return p, tea.Batch(cmd, tea.Tick(refSearchDelay, func(time.Time) tea.Msg {
+			return refSearchMsg{seq: seq}
+		}))
+
+	case refSearchMsg:
+		if msg.seq != p.searchSeq || p.typed() == p.query {
+			return p, nil
+		}
+		p.query = p.typed()
+		p.err = nil
+		return p, p.load(1, 0)
+
+	case refsLoadedMsg:
+		p.setRefs(msg)
+	}
+
+	return p, nil
+}
+
+// View renders the picker as a bordered popup of the given width
+func (p RefPicker) View(width int) []string {
+	selectedStyle := lipgloss.NewStyle().Reverse(true)
+	innerWidth := width - 4
+
+	var lines []string
+	title := " Branch or Tag "
+	borderLen := width - lipgloss.Width(title) - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, "┌─"+title+strings.Repeat("─", borderLen)+"─┐")
+
+	search := p.search
+	search.Width = innerWidth - lipgloss.Width(search.Prompt) - 1
+	lines = append(lines, "│ "+padToWidth(search.View(), innerWidth)+" │")
+	lines = append(lines, "├"+strings.Repeat("─", width-2)+"┤")
+
+	visibleItems := 10
+	count := p.itemCount()
+	start := p.cursor - visibleItems/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + visibleItems
+	if end > count {
+		end = count
+		start = maxInt(0, end-visibleItems)
+	}
+
+	for i := start; i < end; i++ {
+		var name, marks string
+		if i < len(p.refs) {
+			name = p.refs[i].name
+			marks = refMarks(p.refs[i])
+		} else {
+			name = fmt.Sprintf("✎ Use %q", p.typed())
+		}
+		name = truncateStr(name, innerWidth-lipgloss.Width(marks)-1)
+		gap := maxInt(1, innerWidth-lipgloss.Width(name)-lipgloss.Width(marks))
+
+		if i == p.cursor {
+			lines = append(lines, "│ "+selectedStyle.Render(padRight(name+strings.Repeat(" ", gap)+marks, innerWidth))+" │")
+		} else {
+			lines = append(lines, "│ "+padToWidth(name+strings.Repeat(" ", gap)+GrayStyle.Render(marks), innerWidth)+" │")
+		}
+	}
+
+	lines = append(lines, "│ "+padToWidth(GrayStyle.Render(truncateStr(p.status(), innerWidth)), innerWidth)+" │")
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+// refMarks tells the kind of a ref and whether it is protected or the default branch
+func refMarks(ref refItem) string {
+	var marks []string
+	if ref.isDefault {
+		marks = append(marks, "default")
+	}
+	if ref.protected {
+		marks = append(marks, "protected")
+	}
+	if ref.kind == models.RefKindTag {
+		marks = append(marks, "tag")
+	}
+	return strings.Join(marks, " · ")
+}
+
+// status describes what is listed below the refs
+func (p RefPicker) status() string {
+	switch {
+	case p.err != nil:
+		return "⚠ " + p.err.Error()
+	case p.loading && len(p.refs) == 0:
+		return "Loading..."
+	case p.loading:
+		return fmt.Sprintf("%d loaded, loading more...", len(p.refs))
+	case len(p.refs) == 0 && p.query != "":
+		return "No matching branch or tag, Enter checks the typed ref"
+	case len(p.refs) == 0:
+		return "No branches or tags"
+	case p.nextBranchPage > 0 || p.nextTagPage > 0:
+		return fmt.Sprintf("%d loaded, more available", len(p.refs))
+	}
+	return fmt.Sprintf("%d loaded", len(p.refs))
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runFieldInput is an input or a variable of .gitlab-ci.yml in the run form
+type runFieldInput struct {
+	field models.RunField
+	input textinput.Model // Holds the value, only typed in when the field has no options
+}
+
+func newRunFieldInput(field models.RunField) runFieldInput {
+	ti := textinput.New()
+	ti.Placeholder = field.Type
+	if field.Required {
+		ti.Placeholder = "required"
+	}
+	ti.SetValue(field.Value)
+	ti.Width = 30
+	ti.Cursor.Style = CursorStyle
+	return runFieldInput{field: field, input: ti}
+}
+
+// hasOptions returns true if the value is picked from a list
+func (f runFieldInput) hasOptions() bool {
+	return len(f.field.Options) > 0
+}
+
+// cycle picks the next or previous option
+func (f *runFieldInput) cycle(delta int) {
+	options := f.field.Options
+	idx := indexOf(options, f.input.Value())
+	f.input.SetValue(options[(idx+delta+len(options))%len(options)])
+}
+
+// loadRunFields requests the inputs and variables declared in .gitlab-ci.yml at the chosen branch
+func (m *QuickRunModel) loadRunFields() tea.Cmd {
+	ref := m.branch
+	return func() tea.Msg {
+		return loadRunFieldsMsg{ref: ref}
+	}
+}
+
+// SetRunFields shows the inputs and variables of .gitlab-ci.yml at ref in the run form.
+// Values changed in the form and variables copied from a previous pipeline are kept.
+func (m *QuickRunModel) SetRunFields(ref string, fields []models.RunField) {
+	if ref != m.branch {
+		return
+	}
+
+	edited := make(map[string]string)
+	for _, f := range m.runFields {
+		if value := f.input.Value(); value != f.field.Value {
+			edited[runFieldKey(f.field)] = value
+		}
+	}
+
+	m.blurCurrent()
+	m.runFields = nil
+	absorbed := false
+	for _, field := range fields {
+		f := newRunFieldInput(field)
+		if value, ok := edited[runFieldKey(field)]; ok {
+			f.input.SetValue(value)
+		}
+		if !field.Input {
+			// A variable typed as KEY=value moves to its field
+			for i, v := range m.variables {
+				if v.Key == field.Name {
+					f.input.SetValue(v.Value)
+					m.variables = append(m.variables[:i], m.variables[i+1:]...)
+					absorbed = true
+					break
+				}
+			}
+		}
+		m.runFields = append(m.runFields, f)
+	}
+	if absorbed {
+		m.rebuildVarInputs()
+		m.focusedVarIdx = minInt(m.focusedVarIdx, len(m.varInputs)-1)
+	}
+
+	if m.focusedField == QuickRunFieldRunFields && len(m.runFields) == 0 {
+		m.focusedField = QuickRunFieldVariables
+		m.focusedVarIdx = 0
+	}
+	if m.focusedRunIdx >= len(m.runFields) {
+		m.focusedRunIdx = 0
+	}
+	m.focusCurrent()
+}
+
+// runFieldKey tells inputs and variables of the same name apart
+func runFieldKey(field models.RunField) string {
+	if field.Input {
+		return "input:" + field.Name
+	}
+	return "variable:" + field.Name
+}
+
+// runValues returns the inputs and the variables to start the pipeline with, the variables
+// typed as KEY=value overriding the ones of .gitlab-ci.yml
+func (m *QuickRunModel) runValues() (map[string]interface{}, []models.Variable, error) {
+	typed := m.GetVariables()
+	typedKeys := make(map[string]bool)
+	for _, v := range typed {
+		typedKeys[v.Key] = true
+	}
+
+	var inputs map[string]interface{}
+	var variables []models.Variable
+	for _, f := range m.runFields {
+		value := f.input.Value()
+		if !f.field.Input {
+			if !typedKeys[f.field.Name] {
+				variables = append(variables, models.Variable{Key: f.field.Name, Value: value, VariableType: "env_var"})
+			}
+			continue
+		}
+
+		if value == "" {
+			if f.field.Required {
+				return nil, nil, fmt.Errorf("input %s is required", f.field.Name)
+			}
+			if f.field.Type != "string" {
+				// Left to its default
+				continue
+			}
+		}
+		parsed, err := services.ParseInputValue(f.field, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if inputs == nil {
+			inputs = make(map[string]interface{})
+		}
+		inputs[f.field.Name] = parsed
+	}
+
+	return inputs, append(variables, typed...), nil
+}
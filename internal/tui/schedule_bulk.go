@@ -0,0 +1,592 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulkAction is an action applied to each selected schedule
+type bulkAction int
+
+const (
+	bulkEnable bulkAction = iota
+	bulkDisable
+	bulkRun
+	bulkTakeOwnership
+	bulkChangeRef
+	bulkChangeTimezone
+	bulkDelete
+)
+
+// bulkMenu lists the actions of the bulk menu, in order
+var bulkMenu = []bulkAction{bulkEnable, bulkDisable, bulkRun, bulkTakeOwnership, bulkChangeRef, bulkChangeTimezone, bulkDelete}
+
+// String names the action in the bulk menu
+func (a bulkAction) String() string {
+	switch a {
+	case bulkEnable:
+		return "Enable"
+	case bulkDisable:
+		return "Disable"
+	case bulkRun:
+		return "Run pipeline"
+	case bulkTakeOwnership:
+		return "Take ownership"
+	case bulkChangeRef:
+		return "Change branch..."
+	case bulkChangeTimezone:
+		return "Change timezone..."
+	default:
+		return "Delete"
+	}
+}
+
+// progress names the action while it runs, e.g. "Deleting"
+func (a bulkAction) progress() string {
+	switch a {
+	case bulkEnable:
+		return "Enabling"
+	case bulkDisable:
+		return "Disabling"
+	case bulkRun:
+		return "Running"
+	case bulkTakeOwnership:
+		return "Taking ownership of"
+	case bulkChangeRef:
+		return "Changing the branch of"
+	case bulkChangeTimezone:
+		return "Changing the timezone of"
+	default:
+		return "Deleting"
+	}
+}
+
+// done names the finished action, e.g. "Deleted"
+func (a bulkAction) done() string {
+	switch a {
+	case bulkEnable:
+		return "Enabled"
+	case bulkDisable:
+		return "Disabled"
+	case bulkRun:
+		return "Ran"
+	case bulkTakeOwnership:
+		return "Took ownership of"
+	case bulkChangeRef:
+		return "Changed the branch of"
+	case bulkChangeTimezone:
+		return "Changed the timezone of"
+	default:
+		return "Deleted"
+	}
+}
+
+// ConfirmPopup returns the confirmation dialog of the bulk action
+func (a bulkActionMsg) ConfirmPopup() *ConfirmPopup {
+	count := pluralSchedules(len(a.schedules))
+	var question string
+	switch a.action {
+	case bulkEnable:
+		question = fmt.Sprintf("Enable %s?", count)
+	case bulkDisable:
+		question = fmt.Sprintf("Disable %s?", count)
+	case bulkRun:
+		question = fmt.Sprintf("Run a pipeline for %s?", count)
+	case bulkTakeOwnership:
+		question = fmt.Sprintf("Take ownership of %s?", count)
+	case bulkChangeRef:
+		question = fmt.Sprintf("Move %s to %s?", count, a.value)
+	case bulkChangeTimezone:
+		question = fmt.Sprintf("Change the timezone of %s to %s?", count, a.value)
+	default:
+		question = fmt.Sprintf("Delete %s?", count)
+	}
+
+	names := make([]string, 0, 3)
+	for i, s := range a.schedules {
+		if i == 3 {
+			names = append(names, fmt.Sprintf("and %d more", len(a.schedules)-i))
+			break
+		}
+		names = append(names, scheduleLabel(s))
+	}
+
+	return NewConfirmPopup(strings.TrimSuffix(a.action.String(), "..."), question, "", strings.Join(names, ", ")).WithWidth(60)
+}
+
+// bulkResult is the outcome of a bulk action on a schedule
+type bulkResult struct {
+	schedule models.Schedule
+	err      error
+	skipped  bool             // Canceled before its turn
+	pipeline *models.Pipeline // Started by a bulk run
+}
+
+// bulkProgress follows a bulk action applied to the schedules one at a time
+type bulkProgress struct {
+	bulkActionMsg
+	results  []bulkResult
+	canceled bool
+}
+
+// next returns the index of the schedule to act on next
+func (r *bulkProgress) next() int {
+	return len(r.results)
+}
+
+// skipRest records the schedules left out when the run is canceled
+func (r *bulkProgress) skipRest() {
+	for i := r.next(); i < len(r.schedules); i++ {
+		r.results = append(r.results, bulkResult{schedule: r.schedules[i], skipped: true})
+	}
+}
+
+// counts returns how many schedules succeeded, failed and were skipped
+func (r *bulkProgress) counts() (succeeded, failed, skipped int) {
+	for _, result := range r.results {
+		switch {
+		case result.skipped:
+			skipped++
+		case result.err != nil:
+			failed++
+		default:
+			succeeded++
+		}
+	}
+	return succeeded, failed, skipped
+}
+
+// Summary describes the outcome, e.g. "Deleted 4 of 5 schedules, 1 failed"
+func (r *bulkProgress) Summary() string {
+	succeeded, failed, skipped := r.counts()
+	summary := fmt.Sprintf("%s %s", r.action.done(), pluralSchedules(succeeded))
+	if failed > 0 || skipped > 0 {
+		summary = fmt.Sprintf("%s %d of %s", r.action.done(), succeeded, pluralSchedules(len(r.schedules)))
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d canceled", skipped)
+	}
+	return summary
+}
+
+// setPipelines records the pipelines started by a bulk run, found in the reloaded schedules
+// as the last pipeline of each schedule run
+func (r *bulkProgress) setPipelines(schedules []models.Schedule) {
+	if r.action != bulkRun {
+		return
+	}
+	byID := make(map[int]models.Schedule, len(schedules))
+	for _, s := range schedules {
+		byID[s.ID] = s
+	}
+	for i := range r.results {
+		result := &r.results[i]
+		if result.err != nil || result.skipped {
+			continue
+		}
+		last := byID[result.schedule.ID].LastPipeline
+		if last != nil && (result.schedule.LastPipeline == nil || last.ID != result.schedule.LastPipeline.ID) {
+			result.pipeline = last
+		}
+	}
+}
+
+// bulkSummary lists the outcome of a finished bulk action for each schedule
+type bulkSummary struct {
+	title   string
+	results []bulkResult
+	offset  int
+}
+
+func pluralSchedules(n int) string {
+	if n == 1 {
+		return "1 schedule"
+	}
+	return fmt.Sprintf("%d schedules", n)
+}
+
+// scheduleLabel names a schedule in messages, by its description or ID
+func scheduleLabel(s models.Schedule) string {
+	if strings.TrimSpace(s.Description) == "" {
+		return fmt.Sprintf("#%d", s.ID)
+	}
+	return s.Description
+}
+
+// inRange returns true if the row is between the start of the V range and the cursor
+func (m *ScheduleListModel) inRange(row int) bool {
+	if m.rangeAnchor == 0 {
+		return false
+	}
+	for i, s := range m.filtered {
+		if s.ID == m.rangeAnchor {
+			return row >= minInt(i, m.cursor) && row <= maxInt(i, m.cursor)
+		}
+	}
+	return false
+}
+
+// isSelected returns true if the schedule of the row is selected, or in the V range
+func (m *ScheduleListModel) isSelected(row int) bool {
+	return m.selected[m.filtered[row].ID] || m.inRange(row)
+}
+
+// commitRange adds the schedules of the V range to the selection
+func (m *ScheduleListModel) commitRange() {
+	for i := range m.filtered {
+		if m.inRange(i) {
+			m.selected[m.filtered[i].ID] = true
+		}
+	}
+	m.rangeAnchor = 0
+}
+
+// selectedSchedules returns the selected schedules in list order, including those hidden by
+// the search
+func (m *ScheduleListModel) selectedSchedules() []models.Schedule {
+	inRange := make(map[int]bool)
+	for i := range m.filtered {
+		if m.inRange(i) {
+			inRange[m.filtered[i].ID] = true
+		}
+	}
+
+	var schedules []models.Schedule
+	for _, s := range m.schedules {
+		if m.selected[s.ID] || inRange[s.ID] {
+			schedules = append(schedules, s)
+		}
+	}
+	sortSchedules(schedules, m.sortMode)
+	return schedules
+}
+
+// HasSelection returns true if bulk actions apply
+func (m *ScheduleListModel) HasSelection() bool {
+	return len(m.selected) > 0 || m.rangeAnchor != 0
+}
+
+// ClearSelection forgets the selection of the previous project
+func (m *ScheduleListModel) ClearSelection() {
+	m.selected = make(map[int]bool)
+	m.rangeAnchor = 0
+	m.bulkSummary = nil
+}
+
+// pruneSelection drops the selected schedules that no longer exist
+func (m *ScheduleListModel) pruneSelection() {
+	exists := make(map[int]bool, len(m.schedules))
+	for _, s := range m.schedules {
+		exists[s.ID] = true
+	}
+	for id := range m.selected {
+		if !exists[id] {
+			delete(m.selected, id)
+		}
+	}
+	if !exists[m.rangeAnchor] {
+		m.rangeAnchor = 0
+	}
+}
+
+// toggleSelectAll selects every schedule matching the search, or unselects them if they
+// all are already
+func (m *ScheduleListModel) toggleSelectAll() {
+	m.commitRange()
+	all := true
+	for _, s := range m.filtered {
+		if !m.selected[s.ID] {
+			all = false
+			break
+		}
+	}
+	for _, s := range m.filtered {
+		if all {
+			delete(m.selected, s.ID)
+		} else {
+			m.selected[s.ID] = true
+		}
+	}
+}
+
+// confirmBulk asks to apply the action to the selected schedules it changes
+func (m *ScheduleListModel) confirmBulk(action bulkAction) tea.Cmd {
+	var schedules []models.Schedule
+	for _, s := range m.selectedSchedules() {
+		switch {
+		case action == bulkEnable && s.Active,
+			action == bulkDisable && !s.Active,
+			action == bulkTakeOwnership && m.isOwner(&s):
+			continue
+		}
+		schedules = append(schedules, s)
+	}
+
+	switch {
+	case len(schedules) == 0 && action == bulkTakeOwnership:
+		return actionWarning("You already own the selected schedules")
+	case len(schedules) == 0 && action == bulkEnable:
+		return actionWarning("The selected schedules are already active")
+	case len(schedules) == 0 && action == bulkDisable:
+		return actionWarning("The selected schedules are already inactive")
+	case len(schedules) == 0:
+		return actionWarning("Select schedules with Space, V or Ctrl+A first")
+	}
+
+	switch action {
+	case bulkChangeRef:
+		m.pickingRef = true
+		return m.refPicker.Open(commonValue(schedules, func(s models.Schedule) string { return s.Ref }), true)
+	case bulkChangeTimezone:
+		m.timezonePopup = NewSelectPopup("Timezone", services.CommonTimezones).WithWidth(40)
+		current := commonValue(schedules, func(s models.Schedule) string { return s.CronTimezone })
+		for i, tz := range services.CommonTimezones {
+			if tz == current {
+				m.timezonePopup.SetCursor(i)
+			}
+		}
+		return nil
+	}
+
+	m.pendingBulk = bulkActionMsg{action: action, schedules: schedules}
+	m.bulkPopup = m.pendingBulk.ConfirmPopup()
+	return nil
+}
+
+// confirmChange asks to move the selected schedules to the picked ref or timezone
+func (m *ScheduleListModel) confirmChange(action bulkAction, value string) tea.Cmd {
+	var schedules []models.Schedule
+	for _, s := range m.selectedSchedules() {
+		if (action == bulkChangeRef && s.Ref != value) || (action == bulkChangeTimezone && s.CronTimezone != value) {
+			schedules = append(schedules, s)
+		}
+	}
+	if len(schedules) == 0 {
+		return actionWarning("The selected schedules already use " + value)
+	}
+
+	m.pendingBulk = bulkActionMsg{action: action, schedules: schedules, value: value}
+	m.bulkPopup = m.pendingBulk.ConfirmPopup()
+	return nil
+}
+
+// SetBulkRef uses the ref picked for the bulk branch change
+func (m *ScheduleListModel) SetBulkRef(ref string) tea.Cmd {
+	if !m.pickingRef {
+		return nil
+	}
+	m.pickingRef = false
+	return m.confirmChange(bulkChangeRef, ref)
+}
+
+// SetBulkResult marks a schedule with the outcome of the running bulk action
+func (m *ScheduleListModel) SetBulkResult(id int, err error) {
+	m.bulkResults[id] = err
+}
+
+// FinishBulk shows the outcome of the bulk action. Schedules that failed or were left out
+// stay selected, so the action can be tried again.
+func (m *ScheduleListModel) FinishBulk(run *bulkProgress) {
+	m.bulkRunning = false
+	m.bulkResults = nil
+	m.selected = make(map[int]bool)
+	m.rangeAnchor = 0
+	for _, result := range run.results {
+		if result.err != nil || result.skipped {
+			m.selected[result.schedule.ID] = true
+		}
+	}
+	m.pruneSelection()
+	m.bulkSummary = &bulkSummary{title: run.Summary(), results: run.results}
+}
+
+// updateBulk handles the keys of the bulk popups and of a running bulk action.
+// It returns false when none of them is shown.
+func (m ScheduleListModel) updateBulk(msg tea.KeyMsg) (ScheduleListModel, tea.Cmd, bool) {
+	key := msg.String()
+
+	switch {
+	case m.bulkSummary != nil:
+		visible := m.summaryRows()
+		switch key {
+		case "up", "k":
+			if m.bulkSummary.offset > 0 {
+				m.bulkSummary.offset--
+			}
+		case "down", "j":
+			if m.bulkSummary.offset < len(m.bulkSummary.results)-visible {
+				m.bulkSummary.offset++
+			}
+		case "enter", "esc", "q":
+			m.bulkSummary = nil
+		}
+
+	case m.bulkRunning:
+		switch key {
+		case "esc":
+			return m, func() tea.Msg { return cancelBulkMsg{} }, true
+		case "up", "k", "down", "j":
+			// Moving around is fine, other actions wait for the bulk action to finish
+			return m, nil, false
+		}
+
+	case m.bulkPopup != nil:
+		closed, confirmed := handleConfirmKey(m.bulkPopup, key)
+		if !closed {
+			return m, nil, true
+		}
+		m.bulkPopup = nil
+		if !confirmed {
+			return m, nil, true
+		}
+		m.bulkRunning = true
+		m.bulkResults = make(map[int]error)
+		msg := m.pendingBulk
+		return m, func() tea.Msg { return msg }, true
+
+	case m.bulkMenuPopup != nil:
+		switch key {
+		case "up", "k":
+			m.bulkMenuPopup.MoveUp()
+		case "down", "j":
+			m.bulkMenuPopup.MoveDown()
+		case "enter":
+			action := bulkMenu[m.bulkMenuPopup.SelectedIndex()]
+			m.bulkMenuPopup = nil
+			return m, m.confirmBulk(action), true
+		case "esc", "q":
+			m.bulkMenuPopup = nil
+		}
+
+	case m.timezonePopup != nil:
+		switch key {
+		case "up", "k":
+			m.timezonePopup.MoveUp()
+		case "down", "j":
+			m.timezonePopup.MoveDown()
+		case "enter":
+			timezone := m.timezonePopup.Selected()
+			m.timezonePopup = nil
+			return m, m.confirmChange(bulkChangeTimezone, timezone), true
+		case "esc", "q":
+			m.timezonePopup = nil
+		}
+
+	case m.pickingRef:
+		if key == "esc" {
+			m.pickingRef = false
+			return m, nil, true
+		}
+		var cmd tea.Cmd
+		m.refPicker, cmd = m.refPicker.Update(msg)
+		return m, cmd, true
+
+	default:
+		return m, nil, false
+	}
+
+	return m, nil, true
+}
+
+// commonValue returns the value shared by all schedules, "" if they differ
+func commonValue(schedules []models.Schedule, value func(models.Schedule) string) string {
+	if len(schedules) == 0 {
+		return ""
+	}
+	common := value(schedules[0])
+	for _, s := range schedules[1:] {
+		if value(s) != common {
+			return ""
+		}
+	}
+	return common
+}
+
+// selectionMark returns the mark in front of a row: selected, or the outcome of the running
+// bulk action
+func (m ScheduleListModel) selectionMark(row int) (string, lipgloss.Style) {
+	if err, ok := m.bulkResults[m.filtered[row].ID]; ok {
+		if err != nil {
+			return " ✗ ", RedStyle
+		}
+		return " ✓ ", GreenStyle
+	}
+	if m.isSelected(row) {
+		return " ◆ ", BlueStyle
+	}
+	return "   ", GrayStyle
+}
+
+// selectionCount counts the selected schedules for the search row
+func (m ScheduleListModel) selectionCount() string {
+	if !m.HasSelection() {
+		return ""
+	}
+	count := fmt.Sprintf("  %d selected", len(m.selectedSchedules()))
+	if m.rangeAnchor != 0 {
+		count += ", V ends the range"
+	}
+	return count
+}
+
+// summaryWidth is the width of the bulk summary popup
+func (m ScheduleListModel) summaryWidth() int {
+	return minInt(90, m.width-4)
+}
+
+// summaryRows is how many results the bulk summary popup shows at once
+func (m ScheduleListModel) summaryRows() int {
+	return maxInt(3, m.height-8)
+}
+
+// renderBulkSummary lists the outcome for each schedule of the finished bulk action
+func (m ScheduleListModel) renderBulkSummary() string {
+	width := m.summaryWidth()
+	innerWidth := width - 4
+	summary := m.bulkSummary
+
+	var lines []string
+	title := " " + summary.title + " "
+	borderLen := maxInt(0, width-lipgloss.Width(title)-4)
+	lines = append(lines, "┌─"+title+strings.Repeat("─", borderLen)+"─┐")
+
+	end := minInt(len(summary.results), summary.offset+m.summaryRows())
+	for _, result := range summary.results[summary.offset:end] {
+		icon, style := "✓", GreenStyle
+		detail := ""
+		switch {
+		case result.skipped:
+			icon, style = "-", GrayStyle
+			detail = "canceled"
+		case result.err != nil:
+			icon, style = "✗", RedStyle
+			detail = result.err.Error()
+		case result.pipeline != nil:
+			detail = fmt.Sprintf("pipeline #%d", result.pipeline.ID)
+		}
+
+		text := fmt.Sprintf("#%d %s", result.schedule.ID, result.schedule.Description)
+		if detail != "" {
+			text += ": " + detail
+		}
+		line := style.Render(icon) + " " + truncateStr(text, innerWidth-2)
+		lines = append(lines, "│ "+padToWidth(line, innerWidth)+" │")
+	}
+
+	footer := "Enter to close"
+	if len(summary.results) > m.summaryRows() {
+		footer = fmt.Sprintf("%d-%d of %d, ↑↓ to scroll, Enter to close", summary.offset+1, end, len(summary.results))
+	}
+	lines = append(lines, "│ "+padToWidth(GrayStyle.Render(footer), innerWidth)+" │")
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return centerOnScreen(lines, width, m.width, m.height)
+}
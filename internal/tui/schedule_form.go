@@ -37,8 +37,7 @@ type ScheduleFormModel struct {
 	branch      string
 	active      bool
 	timezoneIdx int
-	branchIdx   int
-	branches    []string
+	refPicker   RefPicker
 
 	variables     []models.Variable
 	varInputs     []textinput.Model
@@ -75,7 +74,7 @@ func NewScheduleFormModel() ScheduleFormModel {
 		timezone:     "UTC",
 		branch:       "main",
 		active:       true,
-		branches:     []string{"main", "master"},
+		refPicker:    NewRefPicker(),
 		focusedField: FieldDescription,
 	}
 }
@@ -85,8 +84,10 @@ func (m *ScheduleFormModel) SetSize(width, height int) {
 	m.height = height
 }
 
-func (m *ScheduleFormModel) SetBranches(branches []string) {
-	m.branches = branches
+// SetRef uses a branch or tag picked, or typed in and checked against the repository
+func (m *ScheduleFormModel) SetRef(ref string) {
+	m.branch = ref
+	m.showingPopup = false
 }
 
 func (m *ScheduleFormModel) SetCurrentUser(user *models.User) {
@@ -100,9 +101,8 @@ func (m *ScheduleFormModel) isOwner() bool {
 	return m.currentUser.ID == m.scheduleOwner.ID
 }
 
-func (m *ScheduleFormModel) SetSchedule(schedule *models.Schedule, branches []string, isNew bool) {
+func (m *ScheduleFormModel) SetSchedule(schedule *models.Schedule, isNew bool) {
 	m.isNew = isNew
-	m.branches = branches
 	m.ownershipPopup = nil
 
 	if schedule != nil {
@@ -132,12 +132,6 @@ func (m *ScheduleFormModel) SetSchedule(schedule *models.Schedule, branches []st
 			break
 		}
 	}
-	for i, b := range m.branches {
-		if b == m.branch {
-			m.branchIdx = i
-			break
-		}
-	}
 
 	m.rebuildVarInputs()
 	m.focusedField = FieldDescription
@@ -219,12 +213,25 @@ func (m ScheduleFormModel) Update(msg tea.Msg) (ScheduleFormModel, tea.Cmd) {
 		default:
 			return m.handleInputKey(msg)
 		}
+
+	case refSearchMsg, refsLoadedMsg:
+		if m.showingPopup && m.popupType == "branch" {
+			var cmd tea.Cmd
+			m.refPicker, cmd = m.refPicker.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
 }
 
 func (m ScheduleFormModel) handlePopupKey(msg tea.KeyMsg) (ScheduleFormModel, tea.Cmd) {
+	if msg.String() != "esc" && m.popupType == "branch" {
+		var cmd tea.Cmd
+		m.refPicker, cmd = m.refPicker.Update(msg)
+		return m, cmd
+	}
+
 	switch msg.String() {
 	case "esc":
 		m.showingPopup = false
@@ -238,13 +245,8 @@ func (m ScheduleFormModel) handlePopupKey(msg tea.KeyMsg) (ScheduleFormModel, te
 		}
 	case "enter":
 		m.showingPopup = false
-		if m.popupType == "timezone" {
-			m.timezone = m.popupOptions[m.popupCursor]
-			m.timezoneIdx = m.popupCursor
-		} else {
-			m.branch = m.popupOptions[m.popupCursor]
-			m.branchIdx = m.popupCursor
-		}
+		m.timezone = m.popupOptions[m.popupCursor]
+		m.timezoneIdx = m.popupCursor
 	}
 	return m, nil
 }
@@ -336,8 +338,7 @@ func (m ScheduleFormModel) handleEnter() (ScheduleFormModel, tea.Cmd) {
 	case FieldBranch:
 		m.showingPopup = true
 		m.popupType = "branch"
-		m.popupOptions = m.branches
-		m.popupCursor = m.branchIdx
+		return m, m.refPicker.Open(m.branch, true)
 	case FieldActive:
 		m.active = !m.active
 	case FieldVariables:
@@ -577,8 +578,8 @@ func (m ScheduleFormModel) renderFormPanel(width int) []string {
 	}
 	content = append(content, "") // Gap
 
-	// Target Branch dropdown
-	branchLabel := label.Render(padRight("  Target Branch", labelWidth))
+	// Target branch or tag dropdown
+	branchLabel := label.Render(padRight("  Target Branch/Tag", labelWidth))
 	branchValue := m.branch + " ▾"
 	if m.focusedField == FieldBranch {
 		content = append(content, branchLabel+" "+selected.Render(" "+branchValue+" "))
@@ -707,8 +708,6 @@ func (m ScheduleFormModel) renderHelpPanel(width int) []string {
 }
 
 func (m ScheduleFormModel) renderWithPopup(leftLines, rightLines []string, leftWidth, rightWidth int) string {
-	selectedStyle := lipgloss.NewStyle().Reverse(true)
-
 	// Build background first
 	var bg []string
 	maxLines := maxInt(len(leftLines), len(rightLines))
@@ -726,17 +725,54 @@ func (m ScheduleFormModel) renderWithPopup(leftLines, rightLines []string, leftW
 		bg = append(bg, left+"│"+right)
 	}
 
-	// Build popup - calculate width based on longest option
-	title := " Timezone "
+	// Position popup below the field
+	popupStartY := 6
+	var popup []string
 	if m.popupType == "branch" {
-		title = " Branch "
+		popupStartY = 10
+		popup = m.refPicker.View(minInt(55, leftWidth-15))
+	} else {
+		popup = m.renderTimezonePopup(leftWidth)
+	}
+	popupStartX := 25
+
+	// Build result - overlay popup on background while preserving structure
+	var result []string
+	for i := 0; i < len(bg); i++ {
+		if i >= popupStartY && i < popupStartY+len(popup) {
+			popupIdx := i - popupStartY
+			// Build the line: left border + popup content + remaining space + separator + right panel
+			line := "│ " + strings.Repeat(" ", popupStartX-3) + popup[popupIdx]
+			// Pad to fill left panel
+			lineWidth := lipgloss.Width(line)
+			if lineWidth < leftWidth {
+				line = line + strings.Repeat(" ", leftWidth-lineWidth)
+			}
+			// Add separator and right panel content
+			rightContent := ""
+			if i < len(rightLines) {
+				rightContent = rightLines[i]
+			}
+			rightContent = padToWidth(rightContent, rightWidth)
+			result = append(result, line+"│"+rightContent)
+		} else {
+			result = append(result, bg[i])
+		}
 	}
 
+	return strings.Join(result, "\n")
+}
+
+// renderTimezonePopup lists the timezones around the cursor
+func (m ScheduleFormModel) renderTimezonePopup(leftWidth int) []string {
+	selectedStyle := lipgloss.NewStyle().Reverse(true)
+	title := " Timezone "
+
 	// Find max option width
 	maxOptionWidth := 30
 	for _, opt := range m.popupOptions {
-		if len(opt) > maxOptionWidth {
-			maxOptionWidth = len(opt)
+		if lipgloss.Width(opt) > maxOptionWidth {
+			maxOptionWidth = lipgloss.Width(opt)
 		}
 	}
 	popupWidth := maxOptionWidth + 6 // 2 for borders, 4 for padding
@@ -781,39 +817,7 @@ func (m ScheduleFormModel) renderWithPopup(leftLines, rightLines []string, leftW
 	}
 
 	popup = append(popup, "└"+strings.Repeat("─", popupWidth-2)+"┘")
-
-	// Position popup below the field
-	popupStartY := 6
-	if m.popupType == "branch" {
-		popupStartY = 10
-	}
-	popupStartX := 25
-
-	// Build result - overlay popup on background while preserving structure
-	var result []string
-	for i := 0; i < len(bg); i++ {
-		if i >= popupStartY && i < popupStartY+len(popup) {
-			popupIdx := i - popupStartY
-			// Build the line: left border + popup content + remaining space + separator + right panel
-			line := "│ " + strings.Repeat(" ", popupStartX-3) + popup[popupIdx]
-			// Pad to fill left panel
-			lineWidth := lipgloss.Width(line)
-			if lineWidth < leftWidth {
-				line = line + strings.Repeat(" ", leftWidth-lineWidth)
-			}
-			// Add separator and right panel content
-			rightContent := ""
-			if i < len(rightLines) {
-				rightContent = rightLines[i]
-			}
-			rightContent = padToWidth(rightContent, rightWidth)
-			result = append(result, line+"│"+rightContent)
-		} else {
-			result = append(result, bg[i])
-		}
-	}
-
-	return strings.Join(result, "\n")
+	return popup
 }
 
 func parseKeyValue(text string) (key, value string) {
@@ -0,0 +1,418 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ScheduleHistoryLimit is how many pipelines of a schedule are fetched
+const ScheduleHistoryLimit = 50
+
+// Column widths for the schedule history
+const (
+	ColHistoryStatus   = 12
+	ColHistoryPipeline = 10
+	ColHistoryStarted  = 16
+	ColHistoryDuration = 10
+)
+
+// sparkLevels are the bar heights of the recent runs sparkline, from shortest to longest run
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// ScheduleHistoryModel lists the pipelines triggered by a schedule, with statistics of their outcomes
+type ScheduleHistoryModel struct {
+	width  int
+	height int
+
+	schedule  models.Schedule
+	pipelines []models.Pipeline // Newest first
+	loaded    bool
+
+	cursor       int
+	scrollOffset int
+}
+
+// historyStats summarizes the finished pipelines of a schedule
+type historyStats struct {
+	succeeded   int
+	failed      int
+	avgSeconds  float64
+	lastSuccess *time.Time
+	lastFailure *time.Time
+}
+
+func NewScheduleHistoryModel() ScheduleHistoryModel {
+	return ScheduleHistoryModel{}
+}
+
+func (m *ScheduleHistoryModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetSchedule shows a schedule while its pipelines are loading
+func (m *ScheduleHistoryModel) SetSchedule(schedule models.Schedule) {
+	m.schedule = schedule
+	m.pipelines = nil
+	m.loaded = false
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// SetPipelines sets the pipelines of the schedule, keeping the cursor on the same pipeline
+func (m *ScheduleHistoryModel) SetPipelines(scheduleID int, pipelines []models.Pipeline) {
+	if scheduleID != m.schedule.ID {
+		return
+	}
+
+	selectedID := 0
+	if m.cursor < len(m.pipelines) {
+		selectedID = m.pipelines[m.cursor].ID
+	}
+
+	m.pipelines = pipelines
+	m.loaded = true
+	m.cursor = 0
+	for i, p := range pipelines {
+		if p.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// ScheduleID returns the ID of the schedule shown
+func (m *ScheduleHistoryModel) ScheduleID() int {
+	return m.schedule.ID
+}
+
+// getVisibleRows returns how many pipeline rows can be shown
+func (m *ScheduleHistoryModel) getVisibleRows() int {
+	// Height minus schedule summary, empty line, header row
+	return m.height - 3
+}
+
+// adjustScroll ensures the cursor is visible
+func (m *ScheduleHistoryModel) adjustScroll() {
+	visibleRows := m.getVisibleRows()
+	if visibleRows <= 0 {
+		visibleRows = 5
+	}
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+}
+
+func (m ScheduleHistoryModel) Update(msg tea.Msg) (ScheduleHistoryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc":
+			return m, Navigate(ScreenScheduleList)
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.adjustScroll()
+			}
+		case "down", "j":
+			if m.cursor < len(m.pipelines)-1 {
+				m.cursor++
+				m.adjustScroll()
+			}
+		case "enter":
+			if m.cursor < len(m.pipelines) {
+				pipeline := m.pipelines[m.cursor]
+				return m, NavigateToPipeline(&pipeline)
+			}
+		case "u":
+			return m, func() tea.Msg {
+				return refreshScheduleHistoryMsg{}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m ScheduleHistoryModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	// Split into left (2/3) and right (1/3) columns
+	leftWidth := (m.width * 2) / 3
+	rightWidth := m.width - leftWidth - 1
+
+	leftLines := m.renderLeftColumn(leftWidth)
+	rightLines := m.renderDetailsPanel(rightWidth)
+
+	var result []string
+	maxLines := maxInt(len(leftLines), len(rightLines))
+
+	for i := 0; i < maxLines; i++ {
+		left := ""
+		if i < len(leftLines) {
+			left = leftLines[i]
+		}
+		left = padToWidth(left, leftWidth)
+
+		right := ""
+		if i < len(rightLines) {
+			right = rightLines[i]
+		}
+		right = padToWidth(right, rightWidth)
+
+		result = append(result, left+"│"+right)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func (m ScheduleHistoryModel) renderLeftColumn(width int) []string {
+	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+	indent := "   "
+
+	var lines []string
+
+	// Schedule summary
+	s := m.schedule
+	summary := indent + TitleStyle.Render("History ") +
+		truncateStr(s.Description, maxInt(width/2, 10)) + "  " +
+		BlueStyle.Render(s.Ref) + "  " +
+		GrayStyle.Render(s.Cron)
+	lines = append(lines, summary)
+	lines = append(lines, "")
+
+	headerRow := indent +
+		padRight("Status", ColHistoryStatus) +
+		padRight("Pipeline", ColHistoryPipeline) +
+		padRight("Started", ColHistoryStarted) +
+		padRight("Duration", ColHistoryDuration) +
+		"Commit"
+	lines = append(lines, headerStyle.Render(headerRow))
+
+	visibleRows := m.getVisibleRows()
+	needsScroll := NeedsScrollbar(len(m.pipelines), visibleRows)
+	scrollbar := RenderScrollbar(ScrollbarConfig{
+		TotalItems:   len(m.pipelines),
+		VisibleItems: visibleRows,
+		ScrollOffset: m.scrollOffset,
+		Height:       visibleRows,
+	})
+
+	for rowIdx := 0; rowIdx < visibleRows; rowIdx++ {
+		i := m.scrollOffset + rowIdx
+
+		scrollChar := " "
+		if needsScroll && rowIdx < len(scrollbar) {
+			scrollChar = scrollbar[rowIdx]
+		}
+
+		if i >= len(m.pipelines) {
+			line := ""
+			if i == 0 {
+				if m.loaded {
+					line = indent + GrayStyle.Render("This schedule has not run yet.")
+				} else {
+					line = indent + GrayStyle.Render("Loading pipelines...")
+				}
+			}
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+			continue
+		}
+
+		p := m.pipelines[i]
+		statusIcon, statusStyle := getStatusIconAndStyle(p.Status)
+
+		colStatus := padRight(statusIcon+" "+p.Status, ColHistoryStatus)
+		colPipeline := padRight(fmt.Sprintf("#%d", p.ID), ColHistoryPipeline)
+		colStarted := padRight(formatStarted(p.CreatedAt), ColHistoryStarted)
+		colDuration := padRight(formatPipelineDuration(p), ColHistoryDuration)
+		colCommit := shortSHA(p.SHA)
+
+		if i == m.cursor {
+			plainRow := indent + colStatus + colPipeline + colStarted + colDuration + colCommit
+			lines = append(lines, padToWidth(SelectedStyle.Render(padToWidth(plainRow, width-1)), width-1)+scrollChar)
+		} else {
+			line := indent + statusStyle.Render(colStatus) + colPipeline + colStarted + colDuration + GrayStyle.Render(colCommit)
+			lines = append(lines, padToWidth(line, width-1)+scrollChar)
+		}
+	}
+
+	return lines
+}
+
+func (m ScheduleHistoryModel) renderDetailsPanel(width int) []string {
+	label := YellowStyle.Bold(true)
+	blue := BlueStyle
+	gray := GrayStyle
+
+	var lines []string
+
+	boxTitle := " Details "
+	titleWidth := lipgloss.Width(boxTitle)
+	borderLen := width - titleWidth - 4
+	if borderLen < 0 {
+		borderLen = 0
+	}
+	lines = append(lines, BorderTopLeft+BorderTop+boxTitle+strings.Repeat(BorderTop, borderLen)+BorderTop+BorderTopRight)
+
+	var content []string
+	content = append(content, TitleStyle.Render(truncateStr(m.schedule.Description, width-6)))
+	content = append(content, "")
+
+	if m.loaded && len(m.pipelines) > 0 {
+		stats := computeHistoryStats(m.pipelines)
+
+		content = append(content, label.Render(fmt.Sprintf("Last %d Runs", len(m.pipelines))))
+		if finished := stats.succeeded + stats.failed; finished > 0 {
+			rate := stats.succeeded * 100 / finished
+			rateStyle := GreenStyle
+			if rate < 50 {
+				rateStyle = RedStyle
+			} else if rate < 90 {
+				rateStyle = YellowStyle
+			}
+			content = append(content, "  "+blue.Render("Success rate:")+" "+rateStyle.Render(fmt.Sprintf("%d%%", rate))+
+				gray.Render(fmt.Sprintf(" (%d/%d)", stats.succeeded, finished)))
+			content = append(content, "  "+blue.Render("Avg duration:")+" "+formatJobDuration(stats.avgSeconds, nil))
+		} else {
+			content = append(content, "  "+gray.Render("(No finished pipeline yet)"))
+		}
+		if stats.lastSuccess != nil {
+			content = append(content, "  "+blue.Render("Last success:")+" "+formatTimeAgo(*stats.lastSuccess))
+		}
+		if stats.lastFailure != nil {
+			content = append(content, "  "+blue.Render("Last failure:")+" "+formatTimeAgo(*stats.lastFailure))
+		}
+		content = append(content, "")
+
+		content = append(content, "  "+renderSparkline(m.pipelines, width-8))
+		content = append(content, "  "+gray.Render("oldest → newest"))
+		content = append(content, "")
+	}
+
+	if m.cursor < len(m.pipelines) {
+		p := m.pipelines[m.cursor]
+		icon, style := getStatusIconAndStyle(p.Status)
+
+		content = append(content, label.Render("Pipeline"))
+		linkText := fmt.Sprintf("#%d", p.ID)
+		if p.WebURL != "" {
+			linkText = fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", p.WebURL, blue.Render(linkText))
+		}
+		content = append(content, "  "+linkText+" "+style.Render(icon+" "+p.Status))
+		if p.CreatedAt != nil {
+			content = append(content, "  "+blue.Render("Started:")+" "+p.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		content = append(content, "  "+blue.Render("Duration:")+" "+formatPipelineDuration(p))
+		content = append(content, "  "+blue.Render("Ref:")+" "+p.Ref)
+		if p.SHA != "" {
+			content = append(content, "  "+blue.Render("Commit:")+" "+shortSHA(p.SHA))
+		}
+	}
+
+	for _, line := range content {
+		paddedLine := " " + padToWidth(line, width-4) + " "
+		lines = append(lines, "│"+paddedLine+"│")
+	}
+
+	for len(lines) < m.height-2 {
+		lines = append(lines, "│"+strings.Repeat(" ", width-2)+"│")
+	}
+
+	lines = append(lines, "└"+strings.Repeat("─", width-2)+"┘")
+
+	return lines
+}
+
+// computeHistoryStats counts successes and failures, canceled and running pipelines are left out
+func computeHistoryStats(pipelines []models.Pipeline) historyStats {
+	var stats historyStats
+	var totalSeconds float64
+	var timed int
+
+	for _, p := range pipelines {
+		switch p.Status {
+		case "success":
+			stats.succeeded++
+			if stats.lastSuccess == nil {
+				stats.lastSuccess = p.CreatedAt
+			}
+		case "failed":
+			stats.failed++
+			if stats.lastFailure == nil {
+				stats.lastFailure = p.CreatedAt
+			}
+		default:
+			continue
+		}
+		if seconds := p.DurationSeconds(); seconds > 0 {
+			totalSeconds += seconds
+			timed++
+		}
+	}
+
+	if timed > 0 {
+		stats.avgSeconds = totalSeconds / float64(timed)
+	}
+	return stats
+}
+
+// renderSparkline draws a bar per pipeline, oldest first, coloured by status and as high as its duration
+func renderSparkline(pipelines []models.Pipeline, maxBars int) string {
+	n := len(pipelines)
+	if n > maxBars {
+		n = maxBars
+	}
+	if n <= 0 {
+		return ""
+	}
+
+	var longest float64
+	for _, p := range pipelines[:n] {
+		if seconds := p.DurationSeconds(); seconds > longest {
+			longest = seconds
+		}
+	}
+
+	var b strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		p := pipelines[i]
+		level := 0
+		if longest > 0 {
+			level = int(p.DurationSeconds() / longest * float64(len(sparkLevels)-1))
+		}
+		_, style := getStatusIconAndStyle(p.Status)
+		b.WriteString(style.Render(string(sparkLevels[level])))
+	}
+	return b.String()
+}
+
+// formatPipelineDuration formats how long a pipeline took, or has been running
+func formatPipelineDuration(p models.Pipeline) string {
+	if p.IsRunning() {
+		return formatJobDuration(0, p.CreatedAt)
+	}
+	return formatJobDuration(p.DurationSeconds(), nil)
+}
+
+// shortSHA returns the abbreviated commit SHA shown by GitLab
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
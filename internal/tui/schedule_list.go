@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"glcron/internal/lint"
 	"glcron/internal/models"
 	"strings"
 	"time"
@@ -12,14 +13,24 @@ import (
 )
 
 type ScheduleListModel struct {
-	schedules     []models.Schedule
-	filtered      []models.Schedule
-	cursor        int
-	scrollOffset  int
-	width         int
-	height        int
-	search        textinput.Model
-	searching     bool
+	schedules    []models.Schedule
+	filtered     []models.Schedule
+	cursor       int
+	scrollOffset int
+	width        int
+	height       int
+	search       textinput.Model
+	searching    bool
+	sortMode     ScheduleSort
+
+	// Statistics over recent runs by schedule ID, loaded in the background
+	reliability map[int]*models.ScheduleReliability
+	// Checks of the target branch or tag by schedule ID
+	refChecks map[int]*models.RefCheck
+
+	// Lint findings by schedule ID, updated with the schedules
+	lintOptions lint.Options
+	findings    map[int][]lint.Finding
 
 	// Delete confirmation
 	deletePopup *ConfirmPopup
@@ -29,6 +40,21 @@ type ScheduleListModel struct {
 	currentUser        *models.User
 	takeOwnershipPopup *ConfirmPopup
 	takeOwnershipID    int
+
+	// Multi-select, by schedule ID so it survives searches, sorts and refreshes
+	selected    map[int]bool
+	rangeAnchor int // Schedule the V range starts from, 0 when no range is being selected
+
+	// Bulk actions on the selection
+	bulkMenuPopup *SelectPopup
+	timezonePopup *SelectPopup
+	refPicker     RefPicker
+	pickingRef    bool
+	bulkPopup     *ConfirmPopup
+	pendingBulk   bulkActionMsg
+	bulkRunning   bool
+	bulkResults   map[int]error // Outcome of each schedule done by the running action
+	bulkSummary   *bulkSummary
 }
 
 func NewScheduleListModel() ScheduleListModel {
@@ -38,7 +64,10 @@ func NewScheduleListModel() ScheduleListModel {
 	ti.Width = 30
 
 	return ScheduleListModel{
-		search: ti,
+		search:      ti,
+		lintOptions: lint.NewOptions(nil),
+		selected:    make(map[int]bool),
+		refPicker:   NewRefPicker(),
 	}
 }
 
@@ -48,8 +77,15 @@ func (m *ScheduleListModel) SetSize(width, height int) {
 }
 
 func (m *ScheduleListModel) SetItems(schedules []models.Schedule) {
-	m.schedules = schedules
-	m.filtered = schedules
+	m.schedules = make([]models.Schedule, len(schedules))
+	for i, s := range schedules {
+		s.Reliability = m.reliability[s.ID]
+		s.RefCheck = m.refChecks[s.ID]
+		m.schedules[i] = s
+	}
+	m.runLint()
+	m.pruneSelection()
+	m.applyFilter()
 	if m.cursor >= len(m.filtered) && len(m.filtered) > 0 {
 		m.cursor = len(m.filtered) - 1
 	}
@@ -59,10 +95,80 @@ func (m *ScheduleListModel) SetItems(schedules []models.Schedule) {
 	m.adjustScroll()
 }
 
+// SetReliability shows the statistics of a schedule, keeping the cursor on the same schedule
+func (m *ScheduleListModel) SetReliability(reliability *models.ScheduleReliability) {
+	if m.reliability == nil {
+		m.reliability = make(map[int]*models.ScheduleReliability)
+	}
+	m.reliability[reliability.ScheduleID] = reliability
+
+	selectedID := 0
+	if m.cursor < len(m.filtered) {
+		selectedID = m.filtered[m.cursor].ID
+	}
+	for i := range m.schedules {
+		if m.schedules[i].ID == reliability.ScheduleID {
+			m.schedules[i].Reliability = reliability
+		}
+	}
+	m.applyFilter()
+	for i, s := range m.filtered {
+		if s.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// ClearReliability forgets the statistics of the previous project
+func (m *ScheduleListModel) ClearReliability() {
+	m.reliability = nil
+}
+
+// SetRefChecks flags the schedules whose branch or tag is missing or unprotected
+func (m *ScheduleListModel) SetRefChecks(checks []models.RefCheck) {
+	m.refChecks = make(map[int]*models.RefCheck, len(checks))
+	for i := range checks {
+		m.refChecks[checks[i].ScheduleID] = &checks[i]
+	}
+	for i := range m.schedules {
+		m.schedules[i].RefCheck = m.refChecks[m.schedules[i].ID]
+	}
+	for i := range m.filtered {
+		m.filtered[i].RefCheck = m.refChecks[m.filtered[i].ID]
+	}
+	m.runLint()
+}
+
+// SetLintConfig uses the lint settings of the config file
+func (m *ScheduleListModel) SetLintConfig(config *models.LintConfig) {
+	m.lintOptions = lint.NewOptions(config)
+	m.runLint()
+}
+
+// runLint checks the schedules for the badges of the list
+func (m *ScheduleListModel) runLint() {
+	m.findings = make(map[int][]lint.Finding)
+	for _, f := range lint.Run(m.schedules, m.lintOptions, time.Now()) {
+		m.findings[f.ScheduleID] = append(m.findings[f.ScheduleID], f)
+	}
+}
+
+// ClearRefChecks forgets the checks of the previous project
+func (m *ScheduleListModel) ClearRefChecks() {
+	m.refChecks = nil
+}
+
 func (m *ScheduleListModel) SetCurrentUser(user *models.User) {
 	m.currentUser = user
 }
 
+// IsTyping returns true while a text field has focus, so plain keys aren't shortcuts
+func (m *ScheduleListModel) IsTyping() bool {
+	return m.searching || m.pickingRef
+}
+
 // getVisibleRows returns how many schedule rows can be shown
 func (m *ScheduleListModel) getVisibleRows() int {
 	// Height minus search row, empty line, header row
@@ -106,6 +212,10 @@ func (m *ScheduleListModel) isOwner(schedule *models.Schedule) bool {
 func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m, cmd, handled := m.updateBulk(msg); handled {
+			return m, cmd
+		}
+
 		// Handle take ownership popup
 		if m.takeOwnershipPopup != nil {
 			switch msg.String() {
@@ -202,7 +312,49 @@ func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 			}
 		case "c":
 			return m, Navigate(ScreenNewSchedule)
+		case " ":
+			// Select the row and move on, so Space can be held down
+			if m.cursor < len(m.filtered) {
+				m.commitRange()
+				id := m.filtered[m.cursor].ID
+				if m.selected[id] {
+					delete(m.selected, id)
+				} else {
+					m.selected[id] = true
+				}
+				if m.cursor < len(m.filtered)-1 {
+					m.cursor++
+					m.adjustScroll()
+				}
+			}
+		case "V":
+			if m.rangeAnchor != 0 {
+				m.commitRange()
+			} else if m.cursor < len(m.filtered) {
+				m.rangeAnchor = m.filtered[m.cursor].ID
+			}
+		case "ctrl+a":
+			m.toggleSelectAll()
+		case "esc":
+			if m.rangeAnchor != 0 {
+				m.rangeAnchor = 0
+			} else {
+				m.selected = make(map[int]bool)
+			}
+		case "b":
+			if !m.HasSelection() {
+				return m, actionWarning("Select schedules with Space, V or Ctrl+A first")
+			}
+			options := make([]string, len(bulkMenu))
+			for i, action := range bulkMenu {
+				options[i] = action.String()
+			}
+			m.bulkMenuPopup = NewSelectPopup(fmt.Sprintf("%d selected", len(m.selectedSchedules())), options).WithWidth(30)
 		case "d":
+			if m.HasSelection() {
+				cmd := m.confirmBulk(bulkDelete)
+				return m, cmd
+			}
 			if m.cursor < len(m.filtered) {
 				schedule := m.filtered[m.cursor]
 				m.deleteID = schedule.ID
@@ -212,6 +364,17 @@ func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 				).WithWidth(50)
 			}
 		case "A":
+			if m.HasSelection() {
+				// Enable them all unless they all are active already
+				action := bulkDisable
+				for _, s := range m.selectedSchedules() {
+					if !s.Active {
+						action = bulkEnable
+					}
+				}
+				cmd := m.confirmBulk(action)
+				return m, cmd
+			}
 			if m.cursor < len(m.filtered) {
 				schedule := m.filtered[m.cursor]
 				return m, func() tea.Msg {
@@ -219,16 +382,28 @@ func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 				}
 			}
 		case "r":
+			if m.HasSelection() {
+				cmd := m.confirmBulk(bulkRun)
+				return m, cmd
+			}
 			if m.cursor < len(m.filtered) {
 				schedule := m.filtered[m.cursor]
 				return m, func() tea.Msg {
 					return runScheduleMsg{id: schedule.ID}
 				}
 			}
+		case "w":
+			return m, func() tea.Msg {
+				return toggleWatchMsg{}
+			}
 		case "/":
 			m.searching = true
 			m.search.Focus()
 			return m, textinput.Blink
+		case "s":
+			m.sortMode = m.sortMode.Next()
+			m.applyFilter()
+			m.adjustScroll()
 		case "u":
 			// Refresh schedules from GitLab
 			return m, func() tea.Msg {
@@ -244,6 +419,10 @@ func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 			}
 		case "t":
 			// Take ownership - show confirmation popup
+			if m.HasSelection() {
+				cmd := m.confirmBulk(bulkTakeOwnership)
+				return m, cmd
+			}
 			if m.cursor < len(m.filtered) {
 				schedule := m.filtered[m.cursor]
 				if !m.isOwner(&schedule) {
@@ -260,38 +439,70 @@ func (m ScheduleListModel) Update(msg tea.Msg) (ScheduleListModel, tea.Cmd) {
 					).WithButtons("Yes, Take Ownership", "Cancel").WithWidth(55)
 				}
 			}
+		case "p":
+			// Pipelines triggered by the schedule
+			if m.cursor < len(m.filtered) {
+				schedule := m.filtered[m.cursor]
+				return m, NavigateToScheduleHistory(&schedule)
+			}
 		case "R":
 			// Quick Run - open pipeline run screen
 			return m, Navigate(ScreenQuickRun)
 		}
+
+	case refSearchMsg, refsLoadedMsg:
+		if m.pickingRef {
+			var cmd tea.Cmd
+			m.refPicker, cmd = m.refPicker.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
 }
 
 func (m *ScheduleListModel) filterSchedules() {
-	query := strings.ToLower(m.search.Value())
-	if query == "" {
-		m.filtered = m.schedules
+	m.applyFilter()
+	if m.search.Value() == "" {
 		m.adjustScroll()
 		return
 	}
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// applyFilter rebuilds the visible schedules from the search query and sort mode
+func (m *ScheduleListModel) applyFilter() {
+	query := strings.ToLower(m.search.Value())
 
-	var filtered []models.Schedule
+	filtered := make([]models.Schedule, 0, len(m.schedules))
 	for _, s := range m.schedules {
-		if strings.Contains(strings.ToLower(s.Description), query) ||
-			strings.Contains(strings.ToLower(s.Ref), query) ||
-			strings.Contains(strings.ToLower(s.Cron), query) {
+		if query == "" || scheduleMatches(&s, query) {
 			filtered = append(filtered, s)
 		}
 	}
+	sortSchedules(filtered, m.sortMode)
 	m.filtered = filtered
-	m.cursor = 0
-	m.scrollOffset = 0
 }
 
 func (m ScheduleListModel) View() string {
 	// Show popup as full screen if active
+	if m.bulkSummary != nil {
+		return m.renderBulkSummary()
+	}
+	if m.bulkPopup != nil {
+		return m.bulkPopup.View(m.width, m.height)
+	}
+	if m.bulkMenuPopup != nil {
+		return m.bulkMenuPopup.View(m.width, m.height)
+	}
+	if m.timezonePopup != nil {
+		return m.timezonePopup.View(m.width, m.height)
+	}
+	if m.pickingRef {
+		width := minInt(60, m.width-4)
+		return centerOnScreen(m.refPicker.View(width), width, m.width, m.height)
+	}
 	if m.deletePopup != nil {
 		return m.deletePopup.View(m.width, m.height)
 	}
@@ -333,26 +544,38 @@ func (m ScheduleListModel) renderLeftColumn(width int) []string {
 	headerStyle := lipgloss.NewStyle().Foreground(ColorOrange)
 	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
 	greenStyle := GreenStyle
-	redStyle := RedStyle
 	selectedStyle := SelectedStyle
 
-	// Column widths - Description is wider now
+	// Column widths - Description takes what is left, up to 50
 	const (
-		colActive      = 3
-		colDescription = 50
-		colCron        = 15
-		colBranch      = 18
-		colStatus      = 8
-		colNext        = 8
+		colActive = 3
+		colCron   = 15
+		colBranch = 18
+		colStatus = 8
+		colNext   = 8
+		colRate   = 6
+		colP95    = 9
+		colFails  = 6
 	)
 
 	var lines []string
 	indent := "   "
+	colDescription := width - 1 - len(indent) - colActive - colCron - colBranch - colStatus - colNext - colRate - colP95 - colFails
+	if colDescription > 50 {
+		colDescription = 50
+	}
+	if colDescription < 20 {
+		colDescription = 20
+	}
 
 	// Search row
 	searchIcon := headerStyle.Render("🔍 ")
 	searchField := m.search.View()
 	counter := grayStyle.Render(fmt.Sprintf("  %d/%d", len(m.filtered), len(m.schedules)))
+	if m.sortMode != SortDefault {
+		counter += grayStyle.Render("  sorted by " + m.sortMode.String())
+	}
+	counter += BlueStyle.Render(m.selectionCount())
 	lines = append(lines, indent+searchIcon+searchField+counter)
 	lines = append(lines, "")
 
@@ -363,7 +586,10 @@ func (m ScheduleListModel) renderLeftColumn(width int) []string {
 		padRight("Cron", colCron) +
 		padRight("Branch", colBranch) +
 		padRight("Status", colStatus) +
-		padRight("Next", colNext)
+		padRight("Next", colNext) +
+		padRight("Rate", colRate) +
+		padRight("p95", colP95) +
+		padRight("Fails", colFails)
 	lines = append(lines, headerStyle.Render(headerRow))
 
 	// Calculate visible area and scrollbar
@@ -408,25 +634,7 @@ func (m ScheduleListModel) renderLeftColumn(width int) []string {
 			activeStyle = greenStyle
 		}
 
-		// Pipeline status based on LastPipeline from GitLab
-		statusIcon := "○"
-		statusStyle := grayStyle
-		if schedule.LastPipeline != nil && schedule.LastPipeline.Status != "" {
-			switch schedule.LastPipeline.Status {
-			case "success":
-				statusIcon = "●"
-				statusStyle = greenStyle
-			case "failed":
-				statusIcon = "●"
-				statusStyle = redStyle
-			case "running", "pending":
-				statusIcon = "◐"
-				statusStyle = lipgloss.NewStyle().Foreground(ColorYellow)
-			case "canceled", "skipped", "manual", "scheduled":
-				statusIcon = "○"
-				statusStyle = grayStyle
-			}
-		}
+		statusIcon, statusStyle := scheduleStatusIcon(&schedule)
 
 		// Next run time
 		nextRun := "-"
@@ -439,22 +647,32 @@ func (m ScheduleListModel) renderLeftColumn(width int) []string {
 		colDescStr := padRight(truncateStr(schedule.Description, colDescription-2), colDescription)
 		colCronStr := padRight(truncateStr(schedule.Cron, colCron-2), colCron)
 		colBranchStr := padRight(truncateStr(schedule.Ref, colBranch-2), colBranch)
-		colStatusStr := padRight(statusIcon, colStatus)
+		mark, markStyle := m.selectionMark(i)
+		badge, badgeStyle := lintBadge(m.findings[schedule.ID])
+		colStatusStr := padRight(statusIcon+" "+badge, colStatus)
 		colNextStr := padRight(truncateStr(nextRun, colNext-2), colNext)
+		rate, p95, fails, rateStyle := reliabilityColumns(schedule.Reliability)
+		colRateStr := padRight(rate, colRate)
+		colP95Str := padRight(p95, colP95)
+		colFailsStr := padRight(fails, colFails)
 
 		if i == m.cursor {
 			// Selected row - rectangle highlight
-			plainRow := indent + colActiveStr + colDescStr + colCronStr + colBranchStr + colStatusStr + colNextStr
+			plainRow := mark + colActiveStr + colDescStr + colCronStr + colBranchStr + colStatusStr + colNextStr + colRateStr + colP95Str + colFailsStr
 			lines = append(lines, padToWidth(selectedStyle.Render(plainRow), width-1)+scrollChar)
 		} else {
 			// Normal row
-			row := indent +
+			row := markStyle.Render(mark) +
 				activeStyle.Render(colActiveStr) +
 				colDescStr +
 				colCronStr +
 				colBranchStr +
-				statusStyle.Render(colStatusStr) +
-				colNextStr
+				statusStyle.Render(statusIcon+" ") +
+				badgeStyle.Render(padRight(badge, colStatus-2)) +
+				colNextStr +
+				rateStyle.Render(colRateStr) +
+				grayStyle.Render(colP95Str) +
+				RedStyle.Render(colFailsStr)
 			lines = append(lines, padToWidth(row, width-1)+scrollChar)
 		}
 	}
@@ -462,6 +680,77 @@ func (m ScheduleListModel) renderLeftColumn(width int) []string {
 	return lines
 }
 
+// reliabilityColumns returns the success rate, p95 duration and failure streak columns
+func reliabilityColumns(r *models.ScheduleReliability) (rate, p95, fails string, rateStyle lipgloss.Style) {
+	if r == nil {
+		return "·", "·", "", GrayStyle
+	}
+	rate, rateStyle = formatSuccessRate(r)
+	p95 = "-"
+	if r.P95Duration > 0 {
+		p95 = formatJobDuration(r.P95Duration, nil)
+	}
+	if r.ConsecutiveFailures > 0 {
+		fails = fmt.Sprintf("✗%d", r.ConsecutiveFailures)
+	}
+	return rate, p95, fails, rateStyle
+}
+
+// formatSuccessRate formats the success rate, coloured by how reliable the schedule is
+func formatSuccessRate(r *models.ScheduleReliability) (string, lipgloss.Style) {
+	rate := r.SuccessRate()
+	switch {
+	case rate < 0:
+		return "-", GrayStyle
+	case rate >= 0.9:
+		return fmt.Sprintf("%d%%", int(rate*100)), GreenStyle
+	case rate >= 0.5:
+		return fmt.Sprintf("%d%%", int(rate*100)), YellowStyle
+	default:
+		return fmt.Sprintf("%d%%", int(rate*100)), RedStyle
+	}
+}
+
+// lintBadge counts the lint findings of a schedule for the status column, with the icon
+// and colour of the worst one
+func lintBadge(findings []lint.Finding) (string, lipgloss.Style) {
+	worst := lint.Worst(findings)
+	if worst == nil {
+		return "", GrayStyle
+	}
+	icon, style := lintSeverityIcon(worst.Severity)
+	return fmt.Sprintf("%s%d", icon, len(findings)), style
+}
+
+// lintSeverityIcon returns the icon and colour of a lint severity
+func lintSeverityIcon(severity lint.Severity) (string, lipgloss.Style) {
+	switch severity {
+	case lint.SeverityError:
+		return "✖", RedStyle
+	case lint.SeverityWarning:
+		return "⚠", YellowStyle
+	default:
+		return "ℹ", GrayStyle
+	}
+}
+
+// scheduleStatusIcon returns the status column icon for the schedule's last pipeline
+func scheduleStatusIcon(schedule *models.Schedule) (string, lipgloss.Style) {
+	if schedule.LastPipeline == nil || schedule.LastPipeline.Status == "" {
+		return "○", GrayStyle
+	}
+	switch schedule.LastPipeline.Status {
+	case "success":
+		return "●", GreenStyle
+	case "failed":
+		return "●", RedStyle
+	case "running", "pending":
+		return "◐", lipgloss.NewStyle().Foreground(ColorYellow)
+	default:
+		return "○", GrayStyle
+	}
+}
+
 func (m ScheduleListModel) renderDetailsPanel(width int) []string {
 	// Use shared styles
 	title := TitleStyle
@@ -534,6 +823,22 @@ func (m ScheduleListModel) renderDetailsPanel(width int) []string {
 		content = append(content, "  "+blue.Render("Branch:")+" "+s.Ref)
 		content = append(content, "")
 
+		if findings := m.findings[s.ID]; len(findings) > 0 {
+			content = append(content, label.Render("Lint"))
+			for _, f := range findings {
+				icon, style := lintSeverityIcon(f.Severity)
+				for i, line := range wrapText(f.Message, width-10) {
+					if i == 0 {
+						line = icon + " " + line
+					} else {
+						line = "  " + line
+					}
+					content = append(content, "  "+style.Render(line))
+				}
+			}
+			content = append(content, "")
+		}
+
 		content = append(content, label.Render("Last Pipeline"))
 		pipelineStatus := gray.Render("○ No pipeline")
 		if s.LastPipeline != nil && s.LastPipeline.Status != "" {
@@ -567,6 +872,24 @@ func (m ScheduleListModel) renderDetailsPanel(width int) []string {
 		}
 		content = append(content, "")
 
+		if r := s.Reliability; r != nil {
+			content = append(content, label.Render(fmt.Sprintf("Reliability (last %d runs)", r.Runs)))
+			if r.Finished() == 0 {
+				content = append(content, "  "+gray.Render("(No finished run)"))
+			} else {
+				rate, rateStyle := formatSuccessRate(r)
+				content = append(content, "  "+blue.Render("Success rate:")+" "+rateStyle.Render(rate)+gray.Render(fmt.Sprintf(" (%d/%d)", r.Succeeded, r.Finished())))
+				content = append(content, "  "+blue.Render("Duration:")+" "+formatJobDuration(r.MeanDuration, nil)+" mean, "+formatJobDuration(r.P95Duration, nil)+" p95")
+				if r.ConsecutiveFailures > 0 {
+					content = append(content, "  "+red.Render(fmt.Sprintf("Failed %d time(s) in a row", r.ConsecutiveFailures)))
+				}
+				if r.FailingJob != "" {
+					content = append(content, "  "+blue.Render("Fails most:")+" "+truncateStr(r.FailingJob, width-20)+gray.Render(fmt.Sprintf(" (%d×)", r.FailingJobCount)))
+				}
+			}
+			content = append(content, "")
+		}
+
 		content = append(content, label.Render("Variables"))
 		if len(s.Variables) == 0 {
 			content = append(content, "  "+gray.Render("(No variables)"))
@@ -602,54 +925,3 @@ func (m ScheduleListModel) renderDetailsPanel(width int) []string {
 
 	return lines
 }
-
-func formatRelativeTime(t time.Time) string {
-	now := time.Now()
-	diff := t.Sub(now)
-
-	if diff < 0 {
-		return "past"
-	}
-	if diff < time.Minute {
-		return "<1m"
-	}
-	if diff < time.Hour {
-		return fmt.Sprintf("%dm", int(diff.Minutes()))
-	}
-	if diff < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(diff.Hours()))
-	}
-	return fmt.Sprintf("%dd", int(diff.Hours()/24))
-}
-
-func formatDetailTime(t time.Time) string {
-	now := time.Now()
-	diff := t.Sub(now)
-
-	if diff < 0 {
-		return "Past due"
-	}
-	if diff < time.Minute {
-		return "< 1 minute"
-	}
-	if diff < time.Hour {
-		mins := int(diff.Minutes())
-		if mins == 1 {
-			return "in 1 minute"
-		}
-		return fmt.Sprintf("in %d minutes", mins)
-	}
-	if diff < 24*time.Hour {
-		hours := int(diff.Hours())
-		if hours == 1 {
-			return "in 1 hour"
-		}
-		return fmt.Sprintf("in %d hours", hours)
-	}
-	days := int(diff.Hours() / 24)
-	if days == 1 {
-		return "in 1 day"
-	}
-	return fmt.Sprintf("in %d days", days)
-}
-
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"glcron/internal/models"
+	"sort"
+	"strings"
+)
+
+// ScheduleSort is the order of the schedule tables, cycled with "s"
+type ScheduleSort int
+
+const (
+	SortDefault     ScheduleSort = iota // As returned by GitLab
+	SortNextRun                         // Soonest next run first, unscheduled last
+	SortStatus                          // Failing first, then running, inactive last
+	SortReliability                     // Lowest success rate first, schedules without statistics last
+	SortDescription                     // Alphabetical
+)
+
+// Next returns the sort mode that follows s
+func (s ScheduleSort) Next() ScheduleSort {
+	return (s + 1) % (SortDescription + 1)
+}
+
+// String returns the label shown in the search row
+func (s ScheduleSort) String() string {
+	switch s {
+	case SortNextRun:
+		return "next run"
+	case SortStatus:
+		return "status"
+	case SortReliability:
+		return "reliability"
+	case SortDescription:
+		return "description"
+	default:
+		return "default"
+	}
+}
+
+// sortSchedules orders schedules in place, keeping the original order between equal ones
+func sortSchedules(schedules []models.Schedule, mode ScheduleSort) {
+	if mode == SortDefault {
+		return
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return lessSchedule(&schedules[i], &schedules[j], mode)
+	})
+}
+
+// lessSchedule reports whether a sorts before b in the given mode
+func lessSchedule(a, b *models.Schedule, mode ScheduleSort) bool {
+	switch mode {
+	case SortNextRun:
+		if a.NextRunAt == nil || b.NextRunAt == nil {
+			return a.NextRunAt != nil && b.NextRunAt == nil
+		}
+		return a.NextRunAt.Before(*b.NextRunAt)
+	case SortStatus:
+		return statusRank(a) < statusRank(b)
+	case SortReliability:
+		return models.LessReliable(a.Reliability, b.Reliability)
+	case SortDescription:
+		return strings.ToLower(a.Description) < strings.ToLower(b.Description)
+	}
+	return false
+}
+
+// statusRank orders schedules by how much attention they need
+func statusRank(s *models.Schedule) int {
+	if !s.Active {
+		return 5
+	}
+	if s.LastPipeline == nil {
+		return 4
+	}
+	switch s.LastPipeline.Status {
+	case "failed":
+		return 0
+	case "running", "pending":
+		return 1
+	case "success":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// scheduleMatches returns true if the description, branch or cron contains the lowercase query
+func scheduleMatches(s *models.Schedule, query string) bool {
+	return strings.Contains(strings.ToLower(s.Description), query) ||
+		strings.Contains(strings.ToLower(s.Ref), query) ||
+		strings.Contains(strings.ToLower(s.Cron), query)
+}
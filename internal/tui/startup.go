@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"path"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StartupOptions select what glcron opens on launch, set from the command-line flags
+type StartupOptions struct {
+	ConfigName string   // Open the config with this name
+	ProjectURL string   // Open the config of this project, adding it if its instance is known
+	Screen     Screen   // Screen shown once the project is open, ScreenScheduleList by default
+	GitRemotes []string // Remote URLs of the current git checkout, used when no project is given
+	Output     *Output  // Terminal the program renders to, the bell is rung through it
+}
+
+// StartScreens maps the --screen flag values to screens
+var StartScreens = map[string]Screen{
+	"configs":   ScreenConfigList,
+	"schedules": ScreenScheduleList,
+	"quick-run": ScreenQuickRun,
+}
+
+// ParseStartScreen returns the screen for a --screen flag value
+func ParseStartScreen(name string) (Screen, error) {
+	if name == "" {
+		return ScreenScheduleList, nil
+	}
+	screen, ok := StartScreens[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown screen %q, use configs, schedules or quick-run", name)
+	}
+	return screen, nil
+}
+
+// openStartupProject selects the project requested on the command line once the configs are loaded
+func (m Model) openStartupProject() (Model, tea.Cmd) {
+	opts := m.startup
+	m.startup = nil
+	if opts == nil || opts.Screen == ScreenConfigList {
+		return m, nil
+	}
+
+	idx := -1
+	switch {
+	case opts.ConfigName != "":
+		idx = services.FindConfigByName(m.configs, opts.ConfigName)
+		if idx < 0 {
+			m.log.Error(fmt.Sprintf("No configuration named %q", opts.ConfigName))
+			return m, ClearStatusAfter(10 * time.Second)
+		}
+
+	case opts.ProjectURL != "":
+		idx = services.FindConfigByProject(m.configs, opts.ProjectURL)
+		if idx < 0 {
+			var err error
+			if idx, err = m.addStartupProject(opts.ProjectURL); err != nil {
+				m.log.Error(err.Error())
+				return m, ClearStatusAfter(10 * time.Second)
+			}
+		}
+
+	default:
+		// Open the project of the current git checkout, if it is configured
+		for _, remote := range opts.GitRemotes {
+			if idx = services.FindConfigByProject(m.configs, remote); idx >= 0 {
+				break
+			}
+		}
+		if idx < 0 {
+			return m, nil
+		}
+	}
+
+	m.startScreen = opts.Screen
+	model, cmd := m.handleSelectConfig(selectConfigMsg{index: idx})
+	return model.(Model), cmd
+}
+
+// addStartupProject adds a config for a project URL given on the command line,
+// using the credentials of the instance of its host
+func (m *Model) addStartupProject(projectURL string) (int, error) {
+	instance, ok := m.configService.InstanceForURL(projectURL)
+	if !ok {
+		return -1, fmt.Errorf("no configuration for %s, add it first", projectURL)
+	}
+
+	config := models.Config{
+		Name:       path.Base(strings.TrimSuffix(projectURL, "/")),
+		ProjectURL: strings.TrimSuffix(projectURL, "/"),
+		Token:      instance.Token,
+		AuthType:   instance.AuthType,
+		OAuth:      instance.OAuth,
+	}
+	if err := m.configService.AddConfig(config); err != nil {
+		m.reloadChangedConfigs(err)
+		return -1, err
+	}
+
+	m.configs = m.configService.GetConfigs()
+	m.configList.SetItems(m.configs)
+	return len(m.configs) - 1, nil
+}
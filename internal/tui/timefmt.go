@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// formatRelativeTime formats a future time compactly for table columns, e.g. "3h"
+func formatRelativeTime(t time.Time) string {
+	now := time.Now()
+	diff := t.Sub(now)
+
+	if diff < 0 {
+		return "past"
+	}
+	if diff < time.Minute {
+		return "<1m"
+	}
+	if diff < time.Hour {
+		return fmt.Sprintf("%dm", int(diff.Minutes()))
+	}
+	if diff < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(diff.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(diff.Hours()/24))
+}
+
+// formatTimeAgo formats a past time relative to now, e.g. "3 hours ago"
+func formatTimeAgo(t time.Time) string {
+	diff := time.Since(t)
+
+	if diff < time.Minute {
+		return "just now"
+	}
+	if diff < time.Hour {
+		mins := int(diff.Minutes())
+		if mins == 1 {
+			return "1 minute ago"
+		}
+		return fmt.Sprintf("%d minutes ago", mins)
+	}
+	if diff < 24*time.Hour {
+		hours := int(diff.Hours())
+		if hours == 1 {
+			return "1 hour ago"
+		}
+		return fmt.Sprintf("%d hours ago", hours)
+	}
+	days := int(diff.Hours() / 24)
+	if days == 1 {
+		return "1 day ago"
+	}
+	return fmt.Sprintf("%d days ago", days)
+}
+
+// formatDetailTime formats a future time for detail views, e.g. "in 3 hours"
+func formatDetailTime(t time.Time) string {
+	now := time.Now()
+	diff := t.Sub(now)
+
+	if diff < 0 {
+		return "Past due"
+	}
+	if diff < time.Minute {
+		return "< 1 minute"
+	}
+	if diff < time.Hour {
+		mins := int(diff.Minutes())
+		if mins == 1 {
+			return "in 1 minute"
+		}
+		return fmt.Sprintf("in %d minutes", mins)
+	}
+	if diff < 24*time.Hour {
+		hours := int(diff.Hours())
+		if hours == 1 {
+			return "in 1 hour"
+		}
+		return fmt.Sprintf("in %d hours", hours)
+	}
+	days := int(diff.Hours() / 24)
+	if days == 1 {
+		return "in 1 day"
+	}
+	return fmt.Sprintf("in %d days", days)
+}
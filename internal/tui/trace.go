@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Job log parsing. GitLab logs are raw terminal output with collapsible
+// sections marked by lines like:
+//
+//	section_start:1560896352:build_script[collapsed=true]\r\x1b[0KBuilding
+//	section_end:1560896353:build_script\r\x1b[0K
+
+var (
+	sectionMarkerRe = regexp.MustCompile(`^section_(start|end):(\d+):([A-Za-z0-9_.-]+)(\[[^\]]*\])?`)
+
+	// Cursor movement and erase sequences, only colours (SGR, ending in "m") are kept
+	controlSeqRe = regexp.MustCompile(`\x1b\[[0-9;?]*[A-HJKSTfhlsu]`)
+)
+
+// traceLine is a line of a job log
+type traceLine struct {
+	text    string // With ANSI colours
+	plain   string // Without, used for searching
+	section int    // Innermost section containing the line, -1 for none
+	header  int    // Section started on this line, -1 otherwise
+}
+
+// traceSection is a collapsible section of a job log
+type traceSection struct {
+	name      string
+	parent    int   // Enclosing section, -1 for none
+	start     int64 // Unix timestamps from the markers
+	end       int64
+	ended     bool
+	collapsed bool // Default from the [collapsed=true] option
+}
+
+// Duration returns the section duration in seconds, 0 until it ended
+func (s traceSection) Duration() int64 {
+	if !s.ended {
+		return 0
+	}
+	return s.end - s.start
+}
+
+// parseTrace splits a job log into lines and sections
+func parseTrace(raw string) ([]traceLine, []traceSection) {
+	var lines []traceLine
+	var sections []traceSection
+	var open []int // Stack of open sections
+
+	current := func() int {
+		if len(open) == 0 {
+			return -1
+		}
+		return open[len(open)-1]
+	}
+
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	rawLines := strings.Split(raw, "\n")
+	// A log always ends with a newline, the last element is empty or a line still being written
+	if len(rawLines) > 0 && rawLines[len(rawLines)-1] == "" {
+		rawLines = rawLines[:len(rawLines)-1]
+	}
+
+	for _, rawLine := range rawLines {
+		header := -1
+		text := ""
+		for _, segment := range strings.Split(rawLine, "\r") {
+			segment = controlSeqRe.ReplaceAllString(segment, "")
+			marker := sectionMarkerRe.FindStringSubmatch(ansi.Strip(segment))
+			if marker == nil {
+				// A carriage return overwrites the line, as progress bars do
+				if segment != "" {
+					text = segment
+				}
+				continue
+			}
+
+			timestamp, _ := strconv.ParseInt(marker[2], 10, 64)
+			name := marker[3]
+			if marker[1] == "start" {
+				sections = append(sections, traceSection{
+					name:      name,
+					parent:    current(),
+					start:     timestamp,
+					collapsed: strings.Contains(marker[4], "collapsed=true"),
+				})
+				header = len(sections) - 1
+				open = append(open, header)
+				continue
+			}
+
+			// Close the section, and any left open inside it
+			for i := len(open) - 1; i >= 0; i-- {
+				if sections[open[i]].name == name {
+					sections[open[i]].end = timestamp
+					sections[open[i]].ended = true
+					open = open[:i]
+					break
+				}
+			}
+		}
+
+		if header >= 0 {
+			if text == "" {
+				text = sections[header].name
+			}
+			lines = append(lines, traceLine{text: text, plain: ansi.Strip(text), section: sections[header].parent, header: header})
+			continue
+		}
+		if text == "" && rawLine != "" {
+			// Only markers on this line
+			continue
+		}
+		lines = append(lines, traceLine{text: text, plain: ansi.Strip(text), section: current(), header: -1})
+	}
+
+	return lines, sections
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"glcron/internal/models"
+	"glcron/internal/services"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WatchInterval is how often a watched pipeline is polled
+const WatchInterval = 10 * time.Second
+
+// pipelineWatch follows a pipeline started from glcron until it finishes
+type pipelineWatch struct {
+	config    models.Config // Project of the pipeline, another one may be opened meanwhile
+	pipeline  models.Pipeline
+	jobsDone  int
+	jobsTotal int
+}
+
+// update records the latest state of the pipeline
+func (w *pipelineWatch) update(pipeline *models.Pipeline, jobs []models.PipelineJob) {
+	w.pipeline = *pipeline
+	w.jobsTotal = len(jobs)
+	w.jobsDone = 0
+	for _, job := range jobs {
+		switch job.Status {
+		case "success", "failed", "canceled", "skipped":
+			w.jobsDone++
+		}
+	}
+}
+
+// View renders the progress shown in the header
+func (w *pipelineWatch) View() string {
+	icon, style := getStatusIconAndStyle(w.pipeline.Status)
+	progress := ""
+	if w.jobsTotal > 0 {
+		progress = fmt.Sprintf(" %d/%d jobs", w.jobsDone, w.jobsTotal)
+	}
+	return style.Render(icon) + fmt.Sprintf(" #%d %s%s", w.pipeline.ID, w.pipeline.Status, progress)
+}
+
+// Summary describes the finished pipeline
+func (w *pipelineWatch) Summary() string {
+	return fmt.Sprintf("Pipeline #%d on %s %s (%s)", w.pipeline.ID, w.pipeline.Ref, w.pipeline.Status, w.config.Name)
+}
+
+// notifyCmd rings the terminal bell of output, if any, and shows a desktop notification
+// about the finished pipeline
+func (w *pipelineWatch) notifyCmd(output *Output) tea.Cmd {
+	title := fmt.Sprintf("Pipeline #%d %s", w.pipeline.ID, w.pipeline.Status)
+	body := w.config.Name + " - " + w.pipeline.Ref
+	urgent := w.pipeline.Status == "failed"
+
+	return func() tea.Msg {
+		if output != nil {
+			output.Bell()
+		}
+		// The bell and the status line already tell, a missing notification isn't worth an error
+		_ = services.Notify(title, body, urgent)
+		return nil
+	}
+}